@@ -42,6 +42,7 @@ const (
 	cacheKeyGetCSV  = "getcsv"
 	cacheKeyImages  = "images"
 	cacheKeyAssets  = "assets"
+	cacheKeyMarkup  = "markup"
 )
 
 var defaultCacheConfigs = map[string]cacheConfig{
@@ -55,6 +56,7 @@ var defaultCacheConfigs = map[string]cacheConfig{
 		MaxAge: -1,
 		Dir:    resourcesGenDir,
 	},
+	cacheKeyMarkup: defaultCacheConfig,
 }
 
 type cachesConfig map[string]cacheConfig
@@ -93,6 +95,11 @@ func (f Caches) AssetsCache() *Cache {
 	return f[cacheKeyAssets]
 }
 
+// MarkupCache gets the file cache for the output of the external markup converters.
+func (f Caches) MarkupCache() *Cache {
+	return f[cacheKeyMarkup]
+}
+
 func decodeConfig(p *helpers.PathSpec) (cachesConfig, error) {
 	c := make(cachesConfig)
 	valid := make(map[string]bool)
@@ -64,7 +64,7 @@ dir = "/path/to/c3"
 	decoded, err := decodeConfig(p)
 	assert.NoError(err)
 
-	assert.Equal(4, len(decoded))
+	assert.Equal(5, len(decoded))
 
 	c2 := decoded["getcsv"]
 	assert.Equal("11h0m0s", c2.MaxAge.String())
@@ -112,7 +112,7 @@ dir = "/path/to/c3"
 	decoded, err := decodeConfig(p)
 	assert.NoError(err)
 
-	assert.Equal(4, len(decoded))
+	assert.Equal(5, len(decoded))
 
 	for _, v := range decoded {
 		assert.Equal(time.Duration(0), v.MaxAge)
@@ -141,7 +141,7 @@ func TestDecodeConfigDefault(t *testing.T) {
 
 	assert.NoError(err)
 
-	assert.Equal(4, len(decoded))
+	assert.Equal(5, len(decoded))
 
 	imgConfig := decoded[cacheKeyImages]
 	jsonConfig := decoded[cacheKeyGetJSON]
@@ -223,7 +223,7 @@ func New(cfg DepsCfg) (*Deps, error) {
 		return nil, err
 	}
 
-	contentSpec, err := helpers.NewContentSpec(cfg.Language)
+	contentSpec, err := newContentSpec(cfg.Language, fileCaches)
 	if err != nil {
 		return nil, err
 	}
@@ -266,6 +266,23 @@ func New(cfg DepsCfg) (*Deps, error) {
 	return d, nil
 }
 
+// newContentSpec creates a new ContentSpec that caches the output of
+// the external markup converters in the markup file cache.
+func newContentSpec(cfg config.Provider, fileCaches filecache.Caches) (*helpers.ContentSpec, error) {
+	cs, err := helpers.NewContentSpec(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	markupCache := fileCaches.MarkupCache()
+	cs.ExternalConverterCache = func(id string, create func() ([]byte, error)) ([]byte, error) {
+		_, b, err := markupCache.GetOrCreateBytes(id, create)
+		return b, err
+	}
+
+	return cs, nil
+}
+
 // ForLanguage creates a copy of the Deps with the language dependent
 // parts switched out.
 func (d Deps) ForLanguage(cfg DepsCfg, onCreated func(d *Deps) error) (*Deps, error) {
@@ -277,7 +294,7 @@ func (d Deps) ForLanguage(cfg DepsCfg, onCreated func(d *Deps) error) (*Deps, er
 		return nil, err
 	}
 
-	d.ContentSpec, err = helpers.NewContentSpec(l)
+	d.ContentSpec, err = newContentSpec(l, d.FileCaches)
 	if err != nil {
 		return nil, err
 	}
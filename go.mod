@@ -12,6 +12,7 @@ require (
 	github.com/bep/debounce v1.2.0
 	github.com/bep/gitmap v1.1.0
 	github.com/bep/go-tocss v0.6.0
+	github.com/chai2010/webp v1.1.0
 	github.com/chaseadamsio/goorgeous v1.1.0
 	github.com/cpuguy83/go-md2man v1.0.8 // indirect
 	github.com/disintegration/imaging v1.6.0
@@ -37,6 +38,7 @@ require (
 	github.com/olekukonko/tablewriter v0.0.0-20180506121414-d4647c9c7a84
 	github.com/pkg/errors v0.8.1
 	github.com/russross/blackfriday v1.5.2
+	github.com/rwcarlsen/goexif v0.0.0-20190401172101-9e8deecbddbd
 	github.com/sanity-io/litter v1.1.0
 	github.com/spf13/afero v1.2.2
 	github.com/spf13/cast v1.3.0
@@ -48,6 +50,7 @@ require (
 	github.com/stretchr/testify v1.3.0
 	github.com/tdewolff/minify/v2 v2.3.7
 	github.com/yosssi/ace v0.0.5
+	github.com/yuin/goldmark v1.4.12
 	gocloud.dev v0.13.0
 	golang.org/x/image v0.0.0-20190321063152-3fc05d484e9f
 	golang.org/x/sync v0.0.0-20190227155943-e225da77a7e6
@@ -60,6 +60,22 @@ type ContentSpec struct {
 	BuildExpired bool
 	BuildDrafts  bool
 
+	// The Markdown engine used for content with markup set to "markdown",
+	// either "blackfriday" or "commonmark".
+	defaultMarkdownHandler string
+
+	// How to generate the heading IDs, one of the AutoHeadingIDType constants.
+	autoHeadingIDType string
+
+	tableOfContents TableOfContentsConfig
+
+	// The external markup converters configured in the markup section,
+	// keyed by markup name.
+	externalConverters map[string]ExternalConverter
+
+	// Caches the output of the external markup converters. May be nil.
+	ExternalConverterCache ExternalConverterCache
+
 	Highlight            func(code, lang, optsStr string) (string, error)
 	defatultPygmentsOpts map[string]string
 
@@ -78,6 +94,8 @@ func NewContentSpec(cfg config.Provider) (*ContentSpec, error) {
 		BuildFuture:                cfg.GetBool("buildFuture"),
 		BuildExpired:               cfg.GetBool("buildExpired"),
 		BuildDrafts:                cfg.GetBool("buildDrafts"),
+		defaultMarkdownHandler:     strings.ToLower(cfg.GetString("defaultMarkdownHandler")),
+		autoHeadingIDType:          strings.ToLower(cfg.GetString("autoHeadingIDType")),
 
 		Cfg: cfg,
 	}
@@ -89,6 +107,30 @@ func NewContentSpec(cfg config.Provider) (*ContentSpec, error) {
 	}
 	spec.defatultPygmentsOpts = options
 
+	spec.tableOfContents, err = newTableOfContentsConfig(cfg.GetStringMap("tableOfContents"))
+	if err != nil {
+		return nil, err
+	}
+
+	spec.externalConverters, err = newExternalConverters(cfg.GetStringMap("markup"), cfg.GetString("workingDir"))
+	if err != nil {
+		return nil, err
+	}
+
+	switch spec.defaultMarkdownHandler {
+	case "", "blackfriday", "commonmark":
+	default:
+		return nil, fmt.Errorf("unknown defaultMarkdownHandler %q, must be one of blackfriday or commonmark", spec.defaultMarkdownHandler)
+	}
+
+	if spec.autoHeadingIDType == "" {
+		spec.autoHeadingIDType = AutoHeadingIDTypeBlackfriday
+	}
+
+	if !isValidAutoHeadingIDType(spec.autoHeadingIDType) {
+		return nil, fmt.Errorf("unknown autoHeadingIDType %q, must be one of %s, %s or %s", spec.autoHeadingIDType, AutoHeadingIDTypeGitHub, AutoHeadingIDTypeGitHubASCII, AutoHeadingIDTypeBlackfriday)
+	}
+
 	// Use the Pygmentize on path if present
 	useClassic := false
 	h := newHiglighters(spec)
@@ -238,11 +280,6 @@ func StripHTML(s string) string {
 	return b.String()
 }
 
-// stripEmptyNav strips out empty <nav> tags from content.
-func stripEmptyNav(in []byte) []byte {
-	return bytes.Replace(in, []byte("<nav>\n</nav>\n\n"), []byte(``), -1)
-}
-
 // BytesToHTML converts bytes to type template.HTML.
 func BytesToHTML(b []byte) template.HTML {
 	return template.HTML(string(b))
@@ -311,9 +348,11 @@ func (c *ContentSpec) getHTMLRenderer(defaultFlags int, ctx *RenderingContext) b
 	}
 
 	return &HugoHTMLRenderer{
-		cs:               c,
-		RenderingContext: ctx,
-		Renderer:         blackfriday.HtmlRendererWithParameters(htmlFlags, "", "", renderParameters),
+		cs:                c,
+		RenderingContext:  ctx,
+		Renderer:          blackfriday.HtmlRendererWithParameters(htmlFlags, "", "", renderParameters),
+		headingIDs:        c.headingIDs(ctx),
+		generateHeaderIDs: c.generateHeadingIDs(getMarkdownExtensions(ctx)&blackfriday.EXTENSION_AUTO_HEADER_IDS != 0),
 	}
 }
 
@@ -352,14 +391,34 @@ func getMarkdownExtensions(ctx *RenderingContext) int {
 	return flags
 }
 
+// headingIDs returns the heading IDs in use in the page being rendered.
+func (c *ContentSpec) headingIDs(ctx *RenderingContext) *HeadingIDs {
+	if ctx.HeadingIDs == nil {
+		ctx.HeadingIDs = c.NewHeadingIDs()
+	}
+	return ctx.HeadingIDs
+}
+
+// generateHeadingIDs returns whether Hugo, and not the Markdown engine,
+// should generate the heading IDs. The engines generate them the
+// Blackfriday way.
+func (c *ContentSpec) generateHeadingIDs(autoHeaderIDs bool) bool {
+	return autoHeaderIDs && c.autoHeadingIDType != AutoHeadingIDTypeBlackfriday
+}
+
 func (c ContentSpec) markdownRender(ctx *RenderingContext) []byte {
+	flags := getMarkdownExtensions(ctx)
+	if c.generateHeadingIDs(flags&blackfriday.EXTENSION_AUTO_HEADER_IDS != 0) {
+		flags &^= blackfriday.EXTENSION_AUTO_HEADER_IDS
+	}
+
 	if ctx.RenderTOC {
 		return blackfriday.Markdown(ctx.Content,
 			c.getHTMLRenderer(blackfriday.HTML_TOC, ctx),
-			getMarkdownExtensions(ctx))
+			flags)
 	}
 	return blackfriday.Markdown(ctx.Content, c.getHTMLRenderer(0, ctx),
-		getMarkdownExtensions(ctx))
+		flags)
 }
 
 // getMmarkHTMLRenderer creates a new mmark HTML Renderer with the given configuration.
@@ -384,9 +443,12 @@ func (c *ContentSpec) getMmarkHTMLRenderer(defaultFlags int, ctx *RenderingConte
 	htmlFlags |= mmark.HTML_FOOTNOTE_RETURN_LINKS
 
 	return &HugoMmarkHTMLRenderer{
-		cs:       c,
-		Renderer: mmark.HtmlRendererWithParameters(htmlFlags, "", "", renderParameters),
-		Cfg:      c.Cfg,
+		cs:                c,
+		RenderingContext:  ctx,
+		Renderer:          mmark.HtmlRendererWithParameters(htmlFlags, "", "", renderParameters),
+		Cfg:               c.Cfg,
+		headingIDs:        c.headingIDs(ctx),
+		generateHeaderIDs: c.generateHeadingIDs(true),
 	}
 }
 
@@ -419,47 +481,21 @@ func getMmarkExtensions(ctx *RenderingContext) int {
 }
 
 func (c ContentSpec) mmarkRender(ctx *RenderingContext) []byte {
+	flags := getMmarkExtensions(ctx)
+	if c.generateHeadingIDs(true) {
+		flags &^= mmark.EXTENSION_AUTO_HEADER_IDS
+	}
 	return mmark.Parse(ctx.Content, c.getMmarkHTMLRenderer(0, ctx),
-		getMmarkExtensions(ctx)).Bytes()
+		flags).Bytes()
 }
 
-// ExtractTOC extracts Table of Contents from content.
-func ExtractTOC(content []byte) (newcontent []byte, toc []byte) {
-	if !bytes.Contains(content, []byte("<nav>")) {
-		return content, nil
-	}
-	origContent := make([]byte, len(content))
-	copy(origContent, content)
-	first := []byte(`<nav>
-<ul>`)
-
-	last := []byte(`</ul>
-</nav>`)
-
-	replacement := []byte(`<nav id="TableOfContents">
-<ul>`)
-
-	startOfTOC := bytes.Index(content, first)
-
-	peekEnd := len(content)
-	if peekEnd > 70+startOfTOC {
-		peekEnd = 70 + startOfTOC
+// defaultMarkdownRender renders Markdown with the engine configured in
+// defaultMarkdownHandler.
+func (c ContentSpec) defaultMarkdownRender(ctx *RenderingContext) []byte {
+	if c.defaultMarkdownHandler == "commonmark" {
+		return c.commonmarkRender(ctx)
 	}
-
-	if startOfTOC < 0 {
-		return stripEmptyNav(content), toc
-	}
-	// Need to peek ahead to see if this nav element is actually the right one.
-	correctNav := bytes.Index(content[startOfTOC:peekEnd], []byte(`<li><a href="#`))
-	if correctNav < 0 { // no match found
-		return content, toc
-	}
-	lengthOfTOC := bytes.Index(content[startOfTOC:], last) + len(last)
-	endOfTOC := startOfTOC + lengthOfTOC
-
-	newcontent = append(content[:startOfTOC], content[endOfTOC:]...)
-	toc = append(replacement, origContent[startOfTOC+len(first):endOfTOC]...)
-	return
+	return c.markdownRender(ctx)
 }
 
 // RenderingContext holds contextual information, like content and configuration,
@@ -473,25 +509,48 @@ type RenderingContext struct {
 	Config       *BlackFriday
 	RenderTOC    bool
 	Cfg          config.Provider
+
+	// The table of contents, set up by RenderBytes when RenderTOC is enabled
+	// and filled in by the Markdown engines that support it.
+	TOC *TableOfContents
+
+	// The heading IDs in use in the page, created on first use if not set.
+	// Share it between the renderings of a page to get page-wide unique
+	// heading IDs.
+	HeadingIDs *HeadingIDs
+
+	// The page being rendered, passed on to the render hooks.
+	Page        interface{}
+	RenderHooks *RenderHooks
 }
 
 // RenderBytes renders a []byte.
 func (c ContentSpec) RenderBytes(ctx *RenderingContext) []byte {
+	if ctx.RenderTOC {
+		ctx.TOC = NewTableOfContents(c.tableOfContents)
+	}
+
+	if conv, found := c.externalConverters[ctx.PageFmt]; found {
+		return c.externalRender(ctx, conv)
+	}
+
 	switch ctx.PageFmt {
 	default:
-		return c.markdownRender(ctx)
+		return c.defaultMarkdownRender(ctx)
 	case "markdown":
-		return c.markdownRender(ctx)
+		return c.defaultMarkdownRender(ctx)
+	case "commonmark":
+		return c.commonmarkRender(ctx)
 	case "asciidoc":
-		return getAsciidocContent(ctx)
+		return c.getAsciidocContent(ctx)
 	case "mmark":
 		return c.mmarkRender(ctx)
 	case "rst":
-		return getRstContent(ctx)
+		return c.getRstContent(ctx)
 	case "org":
 		return orgRender(ctx, c)
 	case "pandoc":
-		return getPandocContent(ctx)
+		return c.getPandocContent(ctx)
 	}
 }
 
@@ -650,7 +709,7 @@ func HasAsciidoc() bool {
 
 // getAsciidocContent calls asciidoctor or asciidoc as an external helper
 // to convert AsciiDoc content to HTML.
-func getAsciidocContent(ctx *RenderingContext) []byte {
+func (c ContentSpec) getAsciidocContent(ctx *RenderingContext) []byte {
 	var isAsciidoctor bool
 	path := getAsciidoctorExecPath()
 	if path == "" {
@@ -671,7 +730,7 @@ func getAsciidocContent(ctx *RenderingContext) []byte {
 		args = append(args, "--trace")
 	}
 	args = append(args, "-")
-	return externallyRenderContent(ctx, path, args)
+	return c.externallyRenderContent(ctx, ExternalConverter{Command: path, Args: args, Version: executableVersion(path)})
 }
 
 // HasRst returns whether rst2html is installed on this computer.
@@ -703,7 +762,7 @@ func getPythonExecPath() string {
 
 // getRstContent calls the Python script rst2html as an external helper
 // to convert reStructuredText content to HTML.
-func getRstContent(ctx *RenderingContext) []byte {
+func (c ContentSpec) getRstContent(ctx *RenderingContext) []byte {
 	path := getRstExecPath()
 
 	if path == "" {
@@ -721,10 +780,10 @@ func getRstContent(ctx *RenderingContext) []byte {
 	if runtime.GOOS == "windows" {
 		python := getPythonExecPath()
 		args := []string{path, "--leave-comments", "--initial-header-level=2"}
-		result = externallyRenderContent(ctx, python, args)
+		result = c.externallyRenderContent(ctx, ExternalConverter{Command: python, Args: args, Version: executableVersion(path)})
 	} else {
 		args := []string{"--leave-comments", "--initial-header-level=2"}
-		result = externallyRenderContent(ctx, path, args)
+		result = c.externallyRenderContent(ctx, ExternalConverter{Command: path, Args: args, Version: executableVersion(path)})
 	}
 	// TODO(bep) check if rst2html has a body only option.
 	bodyStart := bytes.Index(result, []byte("<body>\n"))
@@ -744,7 +803,7 @@ func getRstContent(ctx *RenderingContext) []byte {
 }
 
 // getPandocContent calls pandoc as an external helper to convert pandoc markdown to HTML.
-func getPandocContent(ctx *RenderingContext) []byte {
+func (c ContentSpec) getPandocContent(ctx *RenderingContext) []byte {
 	path, err := exec.LookPath("pandoc")
 	if err != nil {
 		jww.ERROR.Println("pandoc not found in $PATH: Please install.\n",
@@ -752,7 +811,7 @@ func getPandocContent(ctx *RenderingContext) []byte {
 		return ctx.Content
 	}
 	args := []string{"--mathjax"}
-	return externallyRenderContent(ctx, path, args)
+	return c.externallyRenderContent(ctx, ExternalConverter{Command: path, Args: args, Version: executableVersion(path)})
 }
 
 func orgRender(ctx *RenderingContext, c ContentSpec) []byte {
@@ -761,28 +820,3 @@ func orgRender(ctx *RenderingContext, c ContentSpec) []byte {
 	return goorgeous.Org(cleanContent,
 		c.getHTMLRenderer(blackfriday.HTML_TOC, ctx))
 }
-
-func externallyRenderContent(ctx *RenderingContext, path string, args []string) []byte {
-	content := ctx.Content
-	cleanContent := bytes.Replace(content, SummaryDivider, []byte(""), 1)
-
-	cmd := exec.Command(path, args...)
-	cmd.Stdin = bytes.NewReader(cleanContent)
-	var out, cmderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &cmderr
-	err := cmd.Run()
-	// Most external helpers exit w/ non-zero exit code only if severe, i.e.
-	// halting errors occurred. -> log stderr output regardless of state of err
-	for _, item := range strings.Split(cmderr.String(), "\n") {
-		item := strings.TrimSpace(item)
-		if item != "" {
-			jww.ERROR.Printf("%s: %s", ctx.DocumentName, item)
-		}
-	}
-	if err != nil {
-		jww.ERROR.Printf("%s rendering %s: %v", path, ctx.DocumentName, err)
-	}
-
-	return normalizeExternalHelperLineFeeds(out.Bytes())
-}
@@ -0,0 +1,201 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"bytes"
+	"html"
+	"strings"
+)
+
+// attribute is an HTML attribute set with a Markdown attribute list.
+type attribute struct {
+	name  string
+	value string
+}
+
+// attributes is an ordered list of HTML attributes.
+type attributes []attribute
+
+// smartQuotesReplacer reverts the quotes that Smartypants may have applied
+// to an attribute list rendered as text.
+var smartQuotesReplacer = strings.NewReplacer("“", `"`, "”", `"`, "‘", "'", "’", "'")
+
+// parseAttributeList parses a Markdown attribute list, e.g.
+// {#custom-id .class data-x=1}. An attribute list holds an ID, classes and
+// key/value pairs separated by spaces, where a value may be quoted.
+// The classes are merged into one class attribute.
+// It returns false if s is not a valid, non-empty attribute list.
+func parseAttributeList(s string) (attributes, bool) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, false
+	}
+	s = s[1 : len(s)-1]
+
+	var attrs attributes
+
+	for pos := 0; ; {
+		for pos < len(s) && isAttributeSpace(s[pos]) {
+			pos++
+		}
+		if pos == len(s) {
+			break
+		}
+
+		switch c := s[pos]; c {
+		case '#', '.':
+			start := pos + 1
+			for pos = start; pos < len(s) && !isAttributeSpace(s[pos]) && !strings.ContainsRune(`{}"'=`, rune(s[pos])); pos++ {
+			}
+			if pos == start {
+				return nil, false
+			}
+			if c == '#' {
+				attrs = attrs.set("id", s[start:pos])
+			} else {
+				attrs = attrs.addClass(s[start:pos])
+			}
+		default:
+			start := pos
+			for pos < len(s) && isAttributeNameChar(s[pos], pos == start) {
+				pos++
+			}
+			if pos == start || pos == len(s) || s[pos] != '=' {
+				return nil, false
+			}
+			name := strings.ToLower(s[start:pos])
+			pos++
+
+			var value string
+			if pos < len(s) && (s[pos] == '"' || s[pos] == '\'') {
+				end := strings.IndexByte(s[pos+1:], s[pos])
+				if end == -1 {
+					return nil, false
+				}
+				value = s[pos+1 : pos+1+end]
+				pos += end + 2
+			} else {
+				start = pos
+				for pos < len(s) && !isAttributeSpace(s[pos]) {
+					pos++
+				}
+				value = s[start:pos]
+			}
+
+			if name == "class" {
+				attrs = attrs.addClass(value)
+			} else {
+				attrs = attrs.set(name, value)
+			}
+		}
+
+		if pos < len(s) && !isAttributeSpace(s[pos]) {
+			return nil, false
+		}
+	}
+
+	return attrs, len(attrs) > 0
+}
+
+// parseRenderedAttributeList parses an attribute list that a Markdown
+// engine has rendered as text, see parseAttributeList.
+func parseRenderedAttributeList(b []byte) (attributes, bool) {
+	s := strings.TrimSpace(string(b))
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, false
+	}
+	return parseAttributeList(smartQuotesReplacer.Replace(html.UnescapeString(s)))
+}
+
+func isAttributeSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isAttributeNameChar(c byte, first bool) bool {
+	if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	if first {
+		return false
+	}
+	return (c >= '0' && c <= '9') || c == '-' || c == '_' || c == ':'
+}
+
+// get returns the value of the named attribute, or an empty string if not set.
+func (a attributes) get(name string) string {
+	for _, attr := range a {
+		if attr.name == name {
+			return attr.value
+		}
+	}
+	return ""
+}
+
+// set sets the named attribute, replacing any existing value.
+func (a attributes) set(name, value string) attributes {
+	for i, attr := range a {
+		if attr.name == name {
+			a[i].value = value
+			return a
+		}
+	}
+	return append(a, attribute{name: name, value: value})
+}
+
+func (a attributes) addClass(class string) attributes {
+	if existing := a.get("class"); existing != "" {
+		return a.set("class", existing+" "+class)
+	}
+	return a.set("class", class)
+}
+
+// toMap returns the attributes other than the ID as a map, or nil if there
+// are none.
+func (a attributes) toMap() map[string]string {
+	var m map[string]string
+	for _, attr := range a {
+		if attr.name == "id" {
+			continue
+		}
+		if m == nil {
+			m = make(map[string]string)
+		}
+		m[attr.name] = attr.value
+	}
+	return m
+}
+
+// without returns the attributes other than the named one.
+func (a attributes) without(name string) attributes {
+	var filtered attributes
+	for _, attr := range a {
+		if attr.name != name {
+			filtered = append(filtered, attr)
+		}
+	}
+	return filtered
+}
+
+// writeTo writes the attributes, each with a leading space,
+// e.g. ` class="note" data-x="1"`.
+func (a attributes) writeTo(b *bytes.Buffer) {
+	for _, attr := range a {
+		b.WriteByte(' ')
+		b.WriteString(attr.name)
+		b.WriteString(`="`)
+		b.WriteString(html.EscapeString(attr.value))
+		b.WriteByte('"')
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAttributeList(t *testing.T) {
+	assert := require.New(t)
+
+	for i, this := range []struct {
+		in     string
+		expect attributes
+	}{
+		{"{#custom-id}", attributes{{"id", "custom-id"}}},
+		{"{#custom-id .a .b data-x=1}", attributes{{"id", "custom-id"}, {"class", "a b"}, {"data-x", "1"}}},
+		{` { .a  title="A title" class='b' } `, attributes{{"class", "a b"}, {"title", "A title"}}},
+		{"{Data-X=}", attributes{{"data-x", ""}}},
+		{"{}", nil},
+		{"{ }", nil},
+		{"{not attrs}", nil},
+		{"{.}", nil},
+		{"{#a#b}", attributes{{"id", "a#b"}}},
+		{`{title="a}`, nil},
+		{`{title="a"b}`, nil},
+		{"{1a=b}", nil},
+		{".a", nil},
+	} {
+		attrs, ok := parseAttributeList(this.in)
+		assert.Equal(this.expect != nil, ok, fmt.Sprintf("[%d] %s", i, this.in))
+		if ok {
+			assert.Equal(this.expect, attrs, fmt.Sprintf("[%d] %s", i, this.in))
+		}
+	}
+}
+
+func TestParseRenderedAttributeList(t *testing.T) {
+	assert := require.New(t)
+
+	attrs, ok := parseRenderedAttributeList([]byte(`{.a title=&ldquo;A &amp; B&rdquo;}`))
+	assert.True(ok)
+	assert.Equal(attributes{{"class", "a"}, {"title", "A & B"}}, attrs)
+
+	var b bytes.Buffer
+	attrs.writeTo(&b)
+	assert.Equal(` class="a" title="A &amp; B"`, b.String())
+	assert.Equal(map[string]string{"class": "a", "title": "A & B"}, attrs.set("id", "b").toMap())
+}
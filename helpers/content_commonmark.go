@@ -0,0 +1,538 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/russross/blackfriday"
+	jww "github.com/spf13/jwalterweatherman"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// newCommonmark creates a new CommonMark Markdown engine configured from
+// the Blackfriday settings in the given context, so the same site config
+// applies whatever Markdown handler is used.
+func (c *ContentSpec) newCommonmark(ctx *RenderingContext) goldmark.Markdown {
+	if ctx.Config == nil {
+		panic(fmt.Sprintf("RenderingContext of %q doesn't have a config", ctx.DocumentID))
+	}
+
+	var (
+		flags           = getMarkdownExtensions(ctx)
+		extensions      []goldmark.Extender
+		parserOptions   []parser.Option
+		rendererOptions []renderer.Option
+	)
+
+	if flags&blackfriday.EXTENSION_TABLES != 0 {
+		extensions = append(extensions, extension.Table)
+	}
+
+	if flags&blackfriday.EXTENSION_STRIKETHROUGH != 0 {
+		extensions = append(extensions, extension.Strikethrough)
+	}
+
+	if flags&blackfriday.EXTENSION_AUTOLINK != 0 {
+		extensions = append(extensions, extension.Linkify)
+	}
+
+	if flags&blackfriday.EXTENSION_DEFINITION_LISTS != 0 {
+		extensions = append(extensions, extension.DefinitionList)
+	}
+
+	if flags&blackfriday.EXTENSION_FOOTNOTES != 0 {
+		footnoteAnchorPrefix := c.footnoteAnchorPrefix
+		if len(ctx.DocumentID) != 0 && !ctx.Config.PlainIDAnchors {
+			footnoteAnchorPrefix = ctx.DocumentID + ":" + footnoteAnchorPrefix
+		}
+		footnoteOptions := []extension.FootnoteOption{
+			extension.WithFootnoteIDPrefix([]byte(footnoteAnchorPrefix)),
+		}
+		if c.footnoteReturnLinkContents != "" {
+			footnoteOptions = append(footnoteOptions, extension.WithFootnoteBacklinkHTML([]byte(c.footnoteReturnLinkContents)))
+		}
+		extensions = append(extensions, extension.NewFootnote(footnoteOptions...))
+	}
+
+	if ctx.Config.TaskLists {
+		extensions = append(extensions, extension.TaskList)
+	}
+
+	if ctx.Config.Smartypants {
+		extensions = append(extensions, extension.Typographer)
+	}
+
+	if flags&blackfriday.EXTENSION_HEADER_IDS != 0 {
+		parserOptions = append(parserOptions, parser.WithHeadingAttribute())
+	}
+
+	headingIDs := &commonmarkHeadingIDs{
+		ids:      c.headingIDs(ctx),
+		idType:   c.autoHeadingIDType,
+		generate: flags&blackfriday.EXTENSION_AUTO_HEADER_IDS != 0,
+	}
+	if len(ctx.DocumentID) != 0 && !ctx.Config.PlainIDAnchors {
+		headingIDs.suffix = ":" + ctx.DocumentID
+	}
+
+	parserOptions = append(parserOptions,
+		parser.WithParagraphTransformers(util.Prioritized(commonmarkParagraphAttributes{}, 150)),
+		parser.WithASTTransformers(
+			util.Prioritized(commonmarkAttributes{}, 100),
+			util.Prioritized(headingIDs, 200)))
+
+	if flags&blackfriday.EXTENSION_HARD_LINE_BREAK != 0 {
+		rendererOptions = append(rendererOptions, html.WithHardWraps())
+	}
+
+	rendererOptions = append(rendererOptions, html.WithXHTML())
+
+	if !ctx.Config.SkipHTML {
+		rendererOptions = append(rendererOptions, html.WithUnsafe())
+	}
+
+	hr := c.newCommonmarkRenderer(ctx)
+	rendererOptions = append(rendererOptions,
+		renderer.WithNodeRenderers(util.Prioritized(hr, 100)))
+
+	md := goldmark.New(
+		goldmark.WithExtensions(extensions...),
+		goldmark.WithParserOptions(parserOptions...),
+		goldmark.WithRendererOptions(rendererOptions...),
+	)
+
+	// The render hooks need to render the inline content of links and
+	// headings on their own.
+	hr.renderer = md.Renderer()
+
+	return md
+}
+
+func (c ContentSpec) commonmarkRender(ctx *RenderingContext) []byte {
+	md := c.newCommonmark(ctx)
+
+	doc := md.Parser().Parse(text.NewReader(ctx.Content))
+
+	var buf bytes.Buffer
+
+	if ctx.TOC != nil {
+		addCommonmarkTOC(ctx.TOC, md.Renderer(), ctx.Content, doc)
+	}
+
+	if err := md.Renderer().Render(&buf, ctx.Content, doc); err != nil {
+		jww.ERROR.Printf("Failed to render %q: %s", ctx.DocumentName, err)
+	}
+
+	return buf.Bytes()
+}
+
+// addCommonmarkTOC adds the headings in doc to the table of contents.
+func addCommonmarkTOC(toc *TableOfContents, r renderer.Renderer, src []byte, doc ast.Node) {
+	var headingCount int
+
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		heading, ok := n.(*ast.Heading)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+
+		var id string
+		if v, found := heading.AttributeString("id"); found {
+			id = string(v.([]byte))
+		} else {
+			id = "toc_" + strconv.Itoa(headingCount)
+		}
+		headingCount++
+
+		var text bytes.Buffer
+		for c := heading.FirstChild(); c != nil; c = c.NextSibling() {
+			if err := r.Render(&text, src, c); err != nil {
+				return ast.WalkStop, err
+			}
+		}
+
+		toc.AddHeading(heading.Level, id, text.String(), commonmarkAttributesMap(heading))
+
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// kindCommonmarkAttributeList is the kind of commonmarkAttributeList.
+var kindCommonmarkAttributeList = ast.NewNodeKind("HugoAttributeList")
+
+// commonmarkAttributeList is a Markdown attribute list split off the end of
+// a paragraph. It's applied to the node before it when the document is
+// parsed, which is either the paragraph or the table it was turned into.
+type commonmarkAttributeList struct {
+	ast.BaseBlock
+	attrs attributes
+}
+
+func (n *commonmarkAttributeList) Kind() ast.NodeKind {
+	return kindCommonmarkAttributeList
+}
+
+func (n *commonmarkAttributeList) Dump(src []byte, level int) {
+	ast.DumpHelper(n, src, level, nil, nil)
+}
+
+// commonmarkParagraphAttributes handles Markdown attribute lists, e.g. {.note},
+// on the last line of a paragraph. A paragraph with only an attribute list
+// is applied to the paragraph, table or block quote right before it.
+type commonmarkParagraphAttributes struct{}
+
+func (commonmarkParagraphAttributes) Transform(node *ast.Paragraph, reader text.Reader, pc parser.Context) {
+	lines := node.Lines()
+	if lines.Len() == 0 {
+		return
+	}
+
+	last := lines.At(lines.Len() - 1)
+	attrs, ok := parseAttributeList(string(last.Value(reader.Source())))
+	if !ok {
+		return
+	}
+
+	parent := node.Parent()
+
+	if lines.Len() == 1 {
+		prev := node.PreviousSibling()
+		if prev == nil {
+			return
+		}
+		switch prev.Kind() {
+		case ast.KindParagraph, ast.KindBlockquote, east.KindTable:
+			setCommonmarkAttributes(prev, attrs)
+			parent.RemoveChild(parent, node)
+		}
+		return
+	}
+
+	lines.SetSliced(0, lines.Len()-1)
+	last = lines.At(lines.Len() - 1)
+	lines.Set(lines.Len()-1, last.TrimRightSpace(reader.Source()))
+
+	parent.InsertAfter(parent, node, &commonmarkAttributeList{attrs: attrs})
+}
+
+// commonmarkAttributes applies the attribute lists split off the paragraphs
+// and makes sure all attribute values are strings, as expected by the
+// HTML renderer.
+type commonmarkAttributes struct{}
+
+func (commonmarkAttributes) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	var lists []*commonmarkAttributeList
+
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		if list, ok := n.(*commonmarkAttributeList); ok {
+			lists = append(lists, list)
+			return ast.WalkSkipChildren, nil
+		}
+
+		for _, attr := range n.Attributes() {
+			if _, ok := attr.Value.([]byte); !ok {
+				n.SetAttribute(attr.Name, []byte(fmt.Sprint(attr.Value)))
+			}
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	for _, list := range lists {
+		if prev := list.PreviousSibling(); prev != nil {
+			setCommonmarkAttributes(prev, list.attrs)
+		}
+		list.Parent().RemoveChild(list.Parent(), list)
+	}
+}
+
+func setCommonmarkAttributes(n ast.Node, attrs attributes) {
+	for _, attr := range attrs {
+		n.SetAttributeString(attr.name, []byte(attr.value))
+	}
+}
+
+// commonmarkAttributesMap returns the attributes of n other than the ID,
+// or nil if there are none.
+func commonmarkAttributesMap(n ast.Node) map[string]string {
+	var attrs attributes
+	for _, attr := range n.Attributes() {
+		if v, ok := attr.Value.([]byte); ok {
+			attrs = append(attrs, attribute{name: string(attr.Name), value: string(v)})
+		}
+	}
+	return attrs.toMap()
+}
+
+// commonmarkHeadingIDs sets the heading IDs once the document is parsed,
+// so they are generated from the heading text in document order and
+// are unique in the page.
+type commonmarkHeadingIDs struct {
+	ids    *HeadingIDs
+	idType string
+
+	// Whether to generate IDs for the headings without one.
+	generate bool
+
+	// Appended to all heading IDs, as done by Blackfriday.
+	suffix string
+}
+
+func (t *commonmarkHeadingIDs) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	src := reader.Source()
+
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		heading, ok := n.(*ast.Heading)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+
+		var id string
+		if v, found := heading.AttributeString("id"); found {
+			id = t.ids.Unique(string(v.([]byte)))
+		} else if t.generate {
+			id = t.ids.Generate(t.headingText(heading, src))
+		} else {
+			return ast.WalkSkipChildren, nil
+		}
+
+		heading.SetAttributeString("id", []byte(id+t.suffix))
+
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// headingText returns the text to generate the heading ID from. Blackfriday
+// uses the Markdown source of the heading, the others the plain text.
+func (t *commonmarkHeadingIDs) headingText(heading *ast.Heading, src []byte) string {
+	if t.idType != AutoHeadingIDTypeBlackfriday {
+		return string(heading.Text(src))
+	}
+
+	var b bytes.Buffer
+	lines := heading.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		b.Write(line.Value(src))
+	}
+	return b.String()
+}
+
+// HugoCommonmarkRenderer renders the CommonMark nodes that Hugo customises,
+// delegating to the same hooks as the Blackfriday based HugoHTMLRenderer.
+type HugoCommonmarkRenderer struct {
+	*HugoHTMLRenderer
+	renderer renderer.Renderer
+}
+
+func (c *ContentSpec) newCommonmarkRenderer(ctx *RenderingContext) *HugoCommonmarkRenderer {
+	return &HugoCommonmarkRenderer{
+		HugoHTMLRenderer: c.getHTMLRenderer(0, ctx).(*HugoHTMLRenderer),
+	}
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r *HugoCommonmarkRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindCodeBlock, r.renderCodeBlock)
+	reg.Register(ast.KindFencedCodeBlock, r.renderCodeBlock)
+	reg.Register(east.KindTaskCheckBox, r.renderTaskCheckBox)
+
+	if r.RenderHooks == nil {
+		return
+	}
+
+	if r.RenderHooks.LinkRenderer != nil {
+		reg.Register(ast.KindLink, r.renderLink)
+		reg.Register(ast.KindAutoLink, r.renderAutoLink)
+	}
+
+	if r.RenderHooks.ImageRenderer != nil {
+		reg.Register(ast.KindImage, r.renderImage)
+	}
+
+	if r.RenderHooks.HeadingRenderer != nil {
+		reg.Register(ast.KindHeading, r.renderHeading)
+	}
+}
+
+// renderChildren renders the children of n, typically the inline content
+// of a link or a heading.
+func (r *HugoCommonmarkRenderer) renderChildren(src []byte, n ast.Node) (string, error) {
+	var buf bytes.Buffer
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if err := r.renderer.Render(&buf, src, c); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+func (r *HugoCommonmarkRenderer) renderLink(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.Link)
+	text, err := r.renderChildren(src, n)
+	if err != nil {
+		return ast.WalkStop, err
+	}
+
+	err = r.RenderHooks.LinkRenderer.RenderLink(w, linkContext{
+		page:        r.Page,
+		destination: string(n.Destination),
+		title:       string(n.Title),
+		text:        text,
+		plainText:   string(n.Text(src)),
+	})
+
+	return ast.WalkSkipChildren, err
+}
+
+func (r *HugoCommonmarkRenderer) renderAutoLink(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.AutoLink)
+	destination := string(n.URL(src))
+	label := string(n.Label(src))
+	if n.AutoLinkType == ast.AutoLinkEmail && !strings.HasPrefix(strings.ToLower(destination), "mailto:") {
+		destination = "mailto:" + destination
+	}
+
+	err := r.RenderHooks.LinkRenderer.RenderLink(w, linkContext{
+		page:        r.Page,
+		destination: destination,
+		text:        template.HTMLEscapeString(label),
+		plainText:   label,
+	})
+
+	return ast.WalkSkipChildren, err
+}
+
+func (r *HugoCommonmarkRenderer) renderImage(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.Image)
+	alt := string(n.Text(src))
+
+	err := r.RenderHooks.ImageRenderer.RenderLink(w, linkContext{
+		page:        r.Page,
+		destination: string(n.Destination),
+		title:       string(n.Title),
+		text:        template.HTMLEscapeString(alt),
+		plainText:   alt,
+	})
+
+	return ast.WalkSkipChildren, err
+}
+
+func (r *HugoCommonmarkRenderer) renderHeading(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.Heading)
+	text, err := r.renderChildren(src, n)
+	if err != nil {
+		return ast.WalkStop, err
+	}
+
+	var anchor string
+	if id, found := n.AttributeString("id"); found {
+		anchor = string(id.([]byte))
+	}
+
+	err = r.RenderHooks.HeadingRenderer.RenderHeading(w, headingContext{
+		page:       r.Page,
+		level:      n.Level,
+		anchor:     anchor,
+		attributes: commonmarkAttributesMap(n),
+		text:       text,
+		plainText:  string(n.Text(src)),
+	})
+	if err != nil {
+		return ast.WalkStop, err
+	}
+
+	_, err = w.WriteString("\n")
+
+	return ast.WalkSkipChildren, err
+}
+
+func (r *HugoCommonmarkRenderer) renderCodeBlock(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	var info string
+	if n, ok := node.(*ast.FencedCodeBlock); ok && n.Info != nil {
+		info = string(n.Info.Segment.Value(src))
+	}
+
+	var code bytes.Buffer
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		code.Write(line.Value(src))
+	}
+
+	var out bytes.Buffer
+	r.BlockCode(&out, code.Bytes(), info)
+	_, err := w.Write(out.Bytes())
+
+	return ast.WalkSkipChildren, err
+}
+
+func (r *HugoCommonmarkRenderer) renderTaskCheckBox(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	if node.(*east.TaskCheckBox).IsChecked {
+		w.WriteString(`<input type="checkbox" checked disabled class="task-list-item">`)
+	} else {
+		w.WriteString(`<input type="checkbox" disabled class="task-list-item">`)
+	}
+
+	return ast.WalkContinue, nil
+}
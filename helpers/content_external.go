@@ -0,0 +1,224 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	jww "github.com/spf13/jwalterweatherman"
+)
+
+// ExternalConverter holds the configuration for an external markup converter,
+// a program that reads the content from stdin and writes HTML to stdout.
+type ExternalConverter struct {
+	// The program to run. Looked up in $PATH if it is not a path.
+	Command string
+
+	// The arguments passed to Command.
+	Args []string
+
+	// The content file extensions, without the dot, to render with this
+	// converter. Defaults to the converter name.
+	Extensions []string
+
+	// The directory to run Command in. A relative path is relative to the
+	// project's working directory. Defaults to the current directory.
+	WorkingDir string
+
+	// The converter version, used to invalidate the cached output when the
+	// converter is upgraded. Defaults to the modification time and size of
+	// the Command executable.
+	Version string
+}
+
+// ExternalConverterCache caches the output of the external markup converters.
+// The create func is only invoked if id is not found in the cache,
+// and its output is not cached if it returns an error.
+type ExternalConverterCache func(id string, create func() ([]byte, error)) ([]byte, error)
+
+func newExternalConverters(config map[string]interface{}, workingDir string) (map[string]ExternalConverter, error) {
+	converters := make(map[string]ExternalConverter)
+
+	for k, v := range config {
+		name := strings.ToLower(k)
+
+		var conv ExternalConverter
+		if err := mapstructure.WeakDecode(v, &conv); err != nil {
+			return nil, fmt.Errorf("failed to decode markup config for %q: %s", name, err)
+		}
+
+		if conv.Command == "" {
+			return nil, fmt.Errorf("markup config for %q must provide a command", name)
+		}
+
+		if len(conv.Extensions) == 0 {
+			conv.Extensions = []string{name}
+		}
+		for i, ext := range conv.Extensions {
+			conv.Extensions[i] = strings.ToLower(strings.TrimPrefix(ext, "."))
+		}
+
+		if conv.WorkingDir != "" && !filepath.IsAbs(conv.WorkingDir) {
+			conv.WorkingDir = filepath.Join(workingDir, conv.WorkingDir)
+		}
+
+		converters[name] = conv
+	}
+
+	return converters, nil
+}
+
+// ResolveMarkup returns the markup identifier for in, which is either a markup
+// name set in front matter or a file extension. The configured external
+// converters take precedence over the built-in markup types.
+func (c *ContentSpec) ResolveMarkup(in string) string {
+	in = strings.ToLower(in)
+
+	if _, found := c.externalConverters[in]; found {
+		return in
+	}
+
+	for name, conv := range c.externalConverters {
+		for _, ext := range conv.Extensions {
+			if ext == in {
+				return name
+			}
+		}
+	}
+
+	return GuessType(in)
+}
+
+// IsExternalConverterExtension reports whether ext, without the dot, is one of
+// the file extensions of the configured external converters.
+func (c *ContentSpec) IsExternalConverterExtension(ext string) bool {
+	for _, conv := range c.externalConverters {
+		for _, e := range conv.Extensions {
+			if e == ext {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c ContentSpec) externalRender(ctx *RenderingContext, conv ExternalConverter) []byte {
+	path, err := exec.LookPath(conv.Command)
+	if err != nil {
+		jww.ERROR.Printf("%s not found in $PATH: Please install.\n"+
+			"                 Leaving %s content unrendered.", conv.Command, ctx.PageFmt)
+		return ctx.Content
+	}
+
+	jww.INFO.Println("Rendering", ctx.DocumentName, "with", path, "...")
+	conv.Command = path
+	if conv.Version == "" {
+		conv.Version = executableVersion(path)
+	}
+
+	return c.externallyRenderContent(ctx, conv)
+}
+
+// externallyRenderContent renders the content in ctx with the given external
+// converter, using the cached output from a previous build if the
+// converter and the content are unchanged.
+func (c ContentSpec) externallyRenderContent(ctx *RenderingContext, conv ExternalConverter) []byte {
+	content := bytes.Replace(ctx.Content, SummaryDivider, []byte(""), 1)
+
+	if c.ExternalConverterCache == nil {
+		result, _ := runExternalConverter(ctx, conv, content)
+		return result
+	}
+
+	var (
+		result []byte
+		ran    bool
+	)
+
+	cached, err := c.ExternalConverterCache(externalConverterCacheKey(ctx.PageFmt, conv, content), func() ([]byte, error) {
+		var err error
+		ran = true
+		result, err = runExternalConverter(ctx, conv, content)
+		return result, err
+	})
+
+	switch {
+	case err == nil:
+		return cached
+	case ran:
+		// Either the converter failed, which is already logged, or the
+		// result could not be written to the cache.
+		return result
+	default:
+		jww.WARN.Printf("Failed to read the cached %s output for %q: %s", ctx.PageFmt, ctx.DocumentName, err)
+		result, _ = runExternalConverter(ctx, conv, content)
+		return result
+	}
+}
+
+// executableVersion returns a version string for the executable in filename
+// that changes when the executable is replaced, e.g. on upgrades.
+func executableVersion(filename string) string {
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d_%d", fi.ModTime().UnixNano(), fi.Size())
+}
+
+func externalConverterCacheKey(markup string, conv ExternalConverter, content []byte) string {
+	var b bytes.Buffer
+	b.WriteString(conv.Command)
+	b.WriteByte(0)
+	b.WriteString(conv.Version)
+	b.WriteByte(0)
+	for _, arg := range conv.Args {
+		b.WriteString(arg)
+		b.WriteByte(0)
+	}
+	b.WriteString(conv.WorkingDir)
+	b.WriteByte(0)
+	b.Write(content)
+
+	return markup + "_" + MD5String(b.String())
+}
+
+func runExternalConverter(ctx *RenderingContext, conv ExternalConverter, content []byte) ([]byte, error) {
+	cmd := exec.Command(conv.Command, conv.Args...)
+	cmd.Dir = conv.WorkingDir
+	cmd.Stdin = bytes.NewReader(content)
+	var out, cmderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &cmderr
+	err := cmd.Run()
+	// Most external helpers exit w/ non-zero exit code only if severe, i.e.
+	// halting errors occurred. -> log stderr output regardless of state of err
+	for _, item := range strings.Split(cmderr.String(), "\n") {
+		item := strings.TrimSpace(item)
+		if item != "" {
+			jww.ERROR.Printf("%s: %s", ctx.DocumentName, item)
+		}
+	}
+	if err != nil {
+		jww.ERROR.Printf("%s rendering %s: %v", conv.Command, ctx.DocumentName, err)
+	}
+
+	return normalizeExternalHelperLineFeeds(out.Bytes()), err
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExternalConverters(t *testing.T) {
+	assert := require.New(t)
+
+	converters, err := newExternalConverters(map[string]interface{}{
+		"asciidoc": map[string]interface{}{
+			"command":    "asciidoctor",
+			"args":       []interface{}{"--no-header-footer", "-"},
+			"workingdir": "docs",
+		},
+		"Textile": map[string]interface{}{
+			"command":    "textile",
+			"extensions": []interface{}{".textile", "TXTL"},
+		},
+	}, "/project")
+	assert.NoError(err)
+	assert.Len(converters, 2)
+
+	asciidoc := converters["asciidoc"]
+	assert.Equal("asciidoctor", asciidoc.Command)
+	assert.Equal([]string{"--no-header-footer", "-"}, asciidoc.Args)
+	assert.Equal([]string{"asciidoc"}, asciidoc.Extensions)
+	assert.Equal(filepath.Join("/project", "docs"), asciidoc.WorkingDir)
+
+	assert.Equal([]string{"textile", "txtl"}, converters["textile"].Extensions)
+
+	_, err = newExternalConverters(map[string]interface{}{
+		"asciidoc": map[string]interface{}{
+			"args": []interface{}{"-"},
+		},
+	}, "/project")
+	assert.Error(err)
+}
+
+func TestResolveMarkup(t *testing.T) {
+	assert := require.New(t)
+
+	v := viper.New()
+	v.Set("markup", map[string]interface{}{
+		"textile": map[string]interface{}{
+			"command":    "textile",
+			"extensions": []string{"txtl", "pdc"},
+		},
+	})
+	c, err := NewContentSpec(v)
+	assert.NoError(err)
+
+	assert.Equal("textile", c.ResolveMarkup("textile"))
+	assert.Equal("textile", c.ResolveMarkup("TXTL"))
+	assert.Equal("textile", c.ResolveMarkup("pdc"))
+	assert.Equal("asciidoc", c.ResolveMarkup("adoc"))
+	assert.Equal("markdown", c.ResolveMarkup("md"))
+	assert.Equal("", c.ResolveMarkup("foo"))
+}
+
+func TestExternallyRenderContentCache(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skip cat on Windows")
+	}
+
+	assert := require.New(t)
+
+	v := viper.New()
+	v.Set("markup", map[string]interface{}{
+		"cat": map[string]interface{}{
+			"command": "cat",
+		},
+		"fail": map[string]interface{}{
+			"command": "false",
+		},
+	})
+	c, err := NewContentSpec(v)
+	assert.NoError(err)
+
+	cache := make(map[string][]byte)
+	var created int
+	c.ExternalConverterCache = func(id string, create func() ([]byte, error)) ([]byte, error) {
+		if b, found := cache[id]; found {
+			return b, nil
+		}
+		created++
+		b, err := create()
+		if err != nil {
+			return nil, err
+		}
+		cache[id] = b
+		return b, nil
+	}
+
+	render := func(markup, content string) string {
+		return string(c.RenderBytes(&RenderingContext{PageFmt: markup, Content: []byte(content), Cfg: c.Cfg, Config: c.BlackFriday}))
+	}
+
+	assert.Equal("<p>Hello</p>\n", render("cat", "<p>Hello</p>\n"))
+	assert.Equal("<p>Hello</p>\n", render("cat", "<p>Hello</p>\n"))
+	assert.Equal(1, created)
+
+	assert.Equal("<p>Hugo</p>\n", render("cat", "<p>Hugo</p>\n"))
+	assert.Equal(2, created)
+
+	assert.Equal("", render("fail", "<p>Hello</p>\n"))
+	assert.Equal("", render("fail", "<p>Hello</p>\n"))
+	assert.Equal(4, created)
+	assert.Len(cache, 2)
+
+	// A new converter version must not reuse the cached output.
+	conv := c.externalConverters["cat"]
+	conv.Version = "2.0"
+	c.externalConverters["cat"] = conv
+	assert.Equal("<p>Hello</p>\n", render("cat", "<p>Hello</p>\n"))
+	assert.Equal(5, created)
+}
+
+func TestExternalConverterCacheKey(t *testing.T) {
+	assert := require.New(t)
+
+	conv := ExternalConverter{Command: "asciidoctor", Args: []string{"-"}}
+	content := []byte("= Title")
+
+	key := externalConverterCacheKey("asciidoc", conv, content)
+	assert.Equal(key, externalConverterCacheKey("asciidoc", conv, content))
+
+	conv.Version = "2.0.10"
+	assert.NotEqual(key, externalConverterCacheKey("asciidoc", conv, content))
+}
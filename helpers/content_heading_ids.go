@@ -0,0 +1,133 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/russross/blackfriday"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The supported values for autoHeadingIDType.
+const (
+	// AutoHeadingIDTypeGitHub generates heading IDs the same way as GitHub:
+	// lower case letters, digits, underscores and hyphens, with the spaces
+	// replaced by hyphens.
+	AutoHeadingIDTypeGitHub = "github"
+
+	// AutoHeadingIDTypeGitHubASCII is like AutoHeadingIDTypeGitHub, but with
+	// accents removed and any other non-ASCII characters left out.
+	AutoHeadingIDTypeGitHubASCII = "github-ascii"
+
+	// AutoHeadingIDTypeBlackfriday generates heading IDs the same way as
+	// Blackfriday. This is the default.
+	AutoHeadingIDTypeBlackfriday = "blackfriday"
+)
+
+func isValidAutoHeadingIDType(idType string) bool {
+	switch idType {
+	case AutoHeadingIDTypeGitHub, AutoHeadingIDTypeGitHubASCII, AutoHeadingIDTypeBlackfriday:
+		return true
+	}
+	return false
+}
+
+// HeadingIDs keeps track of the heading IDs in use in a page, so the IDs are
+// unique across the page's content and shortcodes.
+type HeadingIDs struct {
+	idType string
+
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+// NewHeadingIDs creates a new HeadingIDs that generates heading IDs of the
+// configured autoHeadingIDType.
+func (c *ContentSpec) NewHeadingIDs() *HeadingIDs {
+	return newHeadingIDs(c.autoHeadingIDType)
+}
+
+func newHeadingIDs(idType string) *HeadingIDs {
+	return &HeadingIDs{idType: idType, ids: make(map[string]bool)}
+}
+
+// Generate creates a unique heading ID from the given heading text.
+func (h *HeadingIDs) Generate(text string) string {
+	id := sanitizeAnchorName(text, h.idType)
+	if id == "" {
+		id = "heading"
+	}
+	return h.Unique(id)
+}
+
+// Unique returns id, with a "-1", "-2" etc. suffix if it's already in use,
+// and marks the returned ID as used.
+func (h *HeadingIDs) Unique(id string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	candidate := id
+	for i := 1; h.ids[candidate]; i++ {
+		candidate = id + "-" + strconv.Itoa(i)
+	}
+	h.ids[candidate] = true
+
+	return candidate
+}
+
+// Put marks id as used.
+func (h *HeadingIDs) Put(id string) {
+	h.mu.Lock()
+	h.ids[id] = true
+	h.mu.Unlock()
+}
+
+// sanitizeAnchorName creates a heading ID of the given type from text.
+func sanitizeAnchorName(text, idType string) string {
+	if idType == AutoHeadingIDTypeBlackfriday {
+		return blackfriday.SanitizedAnchorName(text)
+	}
+
+	asciiOnly := idType == AutoHeadingIDTypeGitHubASCII
+	if asciiOnly {
+		t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+		text, _, _ = transform.String(t, text)
+	}
+
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(text) {
+		switch {
+		case asciiOnly && r > unicode.MaxASCII:
+		case r == ' ' || r == '-':
+			b.WriteRune('-')
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	return b.String()
+}
+
+// headingText returns the text of a rendered heading, used to generate
+// its ID.
+func headingText(inner []byte) string {
+	return html.UnescapeString(StripHTML(string(inner)))
+}
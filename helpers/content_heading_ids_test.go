@@ -0,0 +1,83 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeAnchorName(t *testing.T) {
+	for i, test := range []struct {
+		idType string
+		text   string
+		expect string
+	}{
+		{AutoHeadingIDTypeGitHub, "Hello, World!", "hello-world"},
+		{AutoHeadingIDTypeGitHub, "  Foo -- Bar_baz  ", "foo----bar_baz"},
+		{AutoHeadingIDTypeGitHub, "Ünïcode Straße", "ünïcode-straße"},
+		{AutoHeadingIDTypeGitHubASCII, "Ünïcode Straße", "unicode-strae"},
+		{AutoHeadingIDTypeGitHubASCII, "日本語", ""},
+		{AutoHeadingIDTypeBlackfriday, "Hello, World!", "hello-world"},
+		{AutoHeadingIDTypeBlackfriday, "Foo -- Bar", "foo-bar"},
+	} {
+		require.Equal(t, test.expect, sanitizeAnchorName(test.text, test.idType), "[%d] %s", i, test.idType)
+	}
+}
+
+func TestHeadingIDs(t *testing.T) {
+	assert := require.New(t)
+	ids := newHeadingIDs(AutoHeadingIDTypeGitHub)
+
+	assert.Equal("intro", ids.Generate("Intro"))
+	assert.Equal("intro-1", ids.Generate("Intro"))
+	assert.Equal("intro-2", ids.Unique("intro"))
+	assert.Equal("heading", ids.Generate("!!!"))
+
+	ids.Put("taken")
+	assert.Equal("taken-1", ids.Unique("taken"))
+}
+
+func TestAutoHeadingIDType(t *testing.T) {
+	assert := require.New(t)
+
+	v := viper.New()
+	v.Set("autoHeadingIDType", "GitHub")
+	c, err := NewContentSpec(v)
+	assert.NoError(err)
+
+	content := []byte(`## [Foo](http://example.com) Bar
+
+## Foo Bar
+
+## Ünïcode
+`)
+
+	for _, pageFmt := range []string{"markdown", "commonmark"} {
+		ctx := &RenderingContext{RenderTOC: true, PageFmt: pageFmt, Content: content, Cfg: c.Cfg, Config: c.BlackFriday}
+		result := string(c.RenderBytes(ctx))
+
+		assert.Contains(result, `<h2 id="foo-bar"><a href="http://example.com">Foo</a> Bar</h2>`, pageFmt)
+		assert.Contains(result, `<h2 id="foo-bar-1">Foo Bar</h2>`, pageFmt)
+		assert.Contains(result, `<h2 id="ünïcode">Ünïcode</h2>`, pageFmt)
+		assert.Len(ctx.TOC.Headings, 3, pageFmt)
+		assert.Equal("foo-bar-1", ctx.TOC.Headings[1].ID, pageFmt)
+	}
+
+	v.Set("autoHeadingIDType", "foo")
+	_, err = NewContentSpec(v)
+	assert.Error(err)
+}
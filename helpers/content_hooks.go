@@ -0,0 +1,140 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"html/template"
+	"io"
+)
+
+// RenderHooks holds the user provided renderers for the Markdown elements
+// that can be customised. Any of these may be nil, in which case the
+// Markdown engine's default rendering is used.
+type RenderHooks struct {
+	LinkRenderer    LinkRenderer
+	ImageRenderer   LinkRenderer
+	HeadingRenderer HeadingRenderer
+}
+
+// LinkContext is the context passed to a LinkRenderer, for both
+// links and images.
+type LinkContext interface {
+	// The page being rendered.
+	Page() interface{}
+
+	// The link destination, e.g. the URL or image path.
+	Destination() string
+
+	// The link title, if set.
+	Title() string
+
+	// The rendered link text. For images this is the alt text.
+	Text() template.HTML
+
+	// The link text without any markup.
+	PlainText() string
+}
+
+// LinkRenderer renders a Markdown link or image.
+type LinkRenderer interface {
+	RenderLink(w io.Writer, ctx LinkContext) error
+}
+
+// HeadingContext is the context passed to a HeadingRenderer.
+type HeadingContext interface {
+	// The page being rendered.
+	Page() interface{}
+
+	// The heading level, 1 to 6.
+	Level() int
+
+	// The generated or user provided heading ID.
+	Anchor() string
+
+	// The attributes other than the ID set with a Markdown attribute
+	// list, e.g. {.class}, or nil if none.
+	Attributes() map[string]string
+
+	// The rendered heading text.
+	Text() template.HTML
+
+	// The heading text without any markup.
+	PlainText() string
+}
+
+// HeadingRenderer renders a Markdown heading.
+type HeadingRenderer interface {
+	RenderHeading(w io.Writer, ctx HeadingContext) error
+}
+
+type linkContext struct {
+	page        interface{}
+	destination string
+	title       string
+	text        string
+	plainText   string
+}
+
+func (ctx linkContext) Page() interface{} {
+	return ctx.page
+}
+
+func (ctx linkContext) Destination() string {
+	return ctx.destination
+}
+
+func (ctx linkContext) Title() string {
+	return ctx.title
+}
+
+func (ctx linkContext) Text() template.HTML {
+	return template.HTML(ctx.text)
+}
+
+func (ctx linkContext) PlainText() string {
+	return ctx.plainText
+}
+
+type headingContext struct {
+	page       interface{}
+	level      int
+	anchor     string
+	attributes map[string]string
+	text       string
+	plainText  string
+}
+
+func (ctx headingContext) Page() interface{} {
+	return ctx.page
+}
+
+func (ctx headingContext) Level() int {
+	return ctx.level
+}
+
+func (ctx headingContext) Anchor() string {
+	return ctx.anchor
+}
+
+func (ctx headingContext) Attributes() map[string]string {
+	return ctx.attributes
+}
+
+func (ctx headingContext) Text() template.HTML {
+	return template.HTML(ctx.text)
+}
+
+func (ctx headingContext) PlainText() string {
+	return ctx.plainText
+}
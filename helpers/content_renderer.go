@@ -15,11 +15,14 @@ package helpers
 
 import (
 	"bytes"
+	"html/template"
+	"strconv"
 	"strings"
 
 	"github.com/gohugoio/hugo/config"
 	"github.com/miekg/mmark"
 	"github.com/russross/blackfriday"
+	jww "github.com/spf13/jwalterweatherman"
 )
 
 // HugoHTMLRenderer wraps a blackfriday.Renderer, typically a blackfriday.Html
@@ -28,13 +31,35 @@ type HugoHTMLRenderer struct {
 	cs *ContentSpec
 	*RenderingContext
 	blackfriday.Renderer
+
+	// The heading IDs in use in the page.
+	headingIDs *HeadingIDs
+
+	// Whether to generate the heading IDs, see autoHeadingIDType.
+	// If not set, any heading IDs are generated by Blackfriday.
+	generateHeaderIDs bool
+
+	// The last block a Markdown attribute list may apply to.
+	lastBlock attributeTarget
 }
 
 // BlockCode renders a given text as a block of code.
-// Pygments is used if it is setup to handle code fences.
-func (r *HugoHTMLRenderer) BlockCode(out *bytes.Buffer, text []byte, lang string) {
+// Pygments is used if it is setup to handle code fences, with any highlight
+// options set in the code fence's info string, e.g. go {linenos=table}.
+func (r *HugoHTMLRenderer) BlockCode(out *bytes.Buffer, text []byte, info string) {
+	lang, fenceOpts, err := parseCodeFenceInfo(info)
+	if err != nil {
+		jww.ERROR.Printf("Failed to parse code block attributes in %q: %s", r.DocumentName, err)
+	}
+
 	if r.Cfg.GetBool("pygmentsCodeFences") && (lang != "" || r.Cfg.GetBool("pygmentsCodeFencesGuessSyntax")) {
 		opts := r.Cfg.GetString("pygmentsOptions")
+		if fenceOpts != "" {
+			if opts != "" {
+				opts += ","
+			}
+			opts += fenceOpts
+		}
 		str := strings.Trim(string(text), "\n\r")
 		highlighted, _ := r.cs.Highlight(str, lang, opts)
 		out.WriteString(highlighted)
@@ -43,6 +68,252 @@ func (r *HugoHTMLRenderer) BlockCode(out *bytes.Buffer, text []byte, lang string
 	}
 }
 
+// Link renders a link with the link render hook, if provided.
+func (r *HugoHTMLRenderer) Link(out *bytes.Buffer, link []byte, title []byte, content []byte) {
+	if r.RenderHooks == nil || r.RenderHooks.LinkRenderer == nil {
+		r.Renderer.Link(out, link, title, content)
+		return
+	}
+
+	err := r.RenderHooks.LinkRenderer.RenderLink(out, linkContext{
+		page:        r.Page,
+		destination: string(link),
+		title:       string(title),
+		text:        string(content),
+		plainText:   StripHTML(string(content)),
+	})
+	if err != nil {
+		jww.ERROR.Printf("Failed to render link %q in %q: %s", link, r.DocumentName, err)
+	}
+}
+
+// Image renders an image with the image render hook, if provided.
+func (r *HugoHTMLRenderer) Image(out *bytes.Buffer, link []byte, title []byte, alt []byte) {
+	if r.RenderHooks == nil || r.RenderHooks.ImageRenderer == nil {
+		r.Renderer.Image(out, link, title, alt)
+		return
+	}
+
+	err := r.RenderHooks.ImageRenderer.RenderLink(out, linkContext{
+		page:        r.Page,
+		destination: string(link),
+		title:       string(title),
+		text:        template.HTMLEscapeString(string(alt)),
+		plainText:   string(alt),
+	})
+	if err != nil {
+		jww.ERROR.Printf("Failed to render image %q in %q: %s", link, r.DocumentName, err)
+	}
+}
+
+// Header renders a heading with the heading render hook, if provided,
+// and adds it to the table of contents. A Markdown attribute list at the
+// end of the heading, e.g. {#custom-id .class}, is applied to the heading.
+func (r *HugoHTMLRenderer) Header(out *bytes.Buffer, text func() bool, level int, id string) {
+	// Render the heading text first, to look for an attribute list
+	// and to generate the heading ID from it.
+	marker := out.Len()
+	if !text() {
+		out.Truncate(marker)
+		return
+	}
+	inner := append([]byte(nil), out.Bytes()[marker:]...)
+	out.Truncate(marker)
+
+	var attrs attributes
+	generate := id == "" && r.generateHeaderIDs
+
+	if strings.ContainsAny(id, " \t") {
+		// Blackfriday passes everything in {#custom-id .class} as the ID.
+		if a, ok := parseAttributeList("{#" + id + "}"); ok {
+			attrs = a
+			id = attrs.get("id")
+		}
+	} else if start := bytes.LastIndexByte(inner, '{'); start != -1 {
+		// Any other attribute list, e.g. {.class}, is left in the heading text.
+		if a, ok := parseRenderedAttributeList(inner[start:]); ok {
+			attrs = a
+			inner = bytes.TrimRight(inner[:start], " \t")
+			if custom := attrs.get("id"); custom != "" {
+				id = custom
+			} else if id != "" {
+				// Blackfriday generated the ID from the heading text
+				// including the attribute list.
+				id = ""
+				generate = true
+			}
+		}
+	}
+
+	if generate {
+		id = r.headingIDs.Generate(headingText(inner))
+	} else if id != "" {
+		id = r.headingIDs.Unique(id)
+	}
+
+	r.Renderer.Header(out, func() bool {
+		out.Write(inner)
+		return true
+	}, level, id)
+
+	// Get the final heading ID, with any suffix added by Blackfriday.
+	anchor := headerAnchor(out.Bytes()[marker:])
+
+	if r.TOC != nil {
+		r.TOC.AddHeading(level, anchor, string(inner), attrs.toMap())
+	}
+
+	hasHook := r.RenderHooks != nil && r.RenderHooks.HeadingRenderer != nil
+
+	if !hasHook && attrs == nil {
+		return
+	}
+
+	out.Truncate(marker)
+	if out.Len() > 0 {
+		out.WriteByte('\n')
+	}
+
+	if !hasHook {
+		out.WriteString("<h" + strconv.Itoa(level))
+		if anchor != "" {
+			out.WriteString(` id="` + anchor + `"`)
+		}
+		attrs.without("id").writeTo(out)
+		out.WriteByte('>')
+		out.Write(inner)
+		out.WriteString("</h" + strconv.Itoa(level) + ">\n")
+		return
+	}
+
+	err := r.RenderHooks.HeadingRenderer.RenderHeading(out, headingContext{
+		page:       r.Page,
+		level:      level,
+		anchor:     anchor,
+		attributes: attrs.toMap(),
+		text:       string(inner),
+		plainText:  StripHTML(string(inner)),
+	})
+	if err != nil {
+		jww.ERROR.Printf("Failed to render heading %q in %q: %s", inner, r.DocumentName, err)
+	}
+	out.WriteByte('\n')
+}
+
+// headerAnchor returns the value of the id attribute in the opening tag
+// of the given rendered heading, or an empty string if it has none.
+func headerAnchor(heading []byte) string {
+	end := bytes.IndexByte(heading, '>')
+	if end == -1 {
+		return ""
+	}
+	tag := heading[:end]
+	start := bytes.Index(tag, []byte(`id="`))
+	if start == -1 {
+		return ""
+	}
+	tag = tag[start+len(`id="`):]
+	end = bytes.IndexByte(tag, '"')
+	if end == -1 {
+		return ""
+	}
+	return string(tag[:end])
+}
+
+// Paragraph renders a paragraph. A Markdown attribute list on the last
+// line of the paragraph, e.g. {.note}, is applied to the paragraph.
+// A paragraph with only an attribute list is applied to the paragraph,
+// table or block quote right before it.
+func (r *HugoHTMLRenderer) Paragraph(out *bytes.Buffer, text func() bool) {
+	var start int
+	marker := out.Len()
+	r.Renderer.Paragraph(out, func() bool {
+		start = out.Len()
+		return text()
+	})
+
+	if out.Len() == marker {
+		return
+	}
+
+	content := out.Bytes()[start : out.Len()-len("</p>\n")]
+	lineStart := bytes.LastIndexByte(content, '\n') + 1
+	attrs, ok := parseRenderedAttributeList(content[lineStart:])
+
+	switch {
+	case !ok:
+	case lineStart == 0:
+		if r.lastBlock.out != out || r.lastBlock.end != marker {
+			// Nothing to apply the attributes to, leave them as text.
+			break
+		}
+		out.Truncate(marker)
+		r.lastBlock.insertAttributes(attrs)
+		return
+	default:
+		paragraph := append([]byte(nil), content[:lineStart-1]...)
+		out.Truncate(start - len("<p>"))
+		out.WriteString("<p")
+		attrs.writeTo(out)
+		out.WriteByte('>')
+		out.Write(paragraph)
+		out.WriteString("</p>\n")
+	}
+
+	r.lastBlock = newAttributeTarget(out, marker)
+}
+
+// BlockQuote renders a block quote.
+func (r *HugoHTMLRenderer) BlockQuote(out *bytes.Buffer, text []byte) {
+	marker := out.Len()
+	r.Renderer.BlockQuote(out, text)
+	r.lastBlock = newAttributeTarget(out, marker)
+}
+
+// Table renders a table.
+func (r *HugoHTMLRenderer) Table(out *bytes.Buffer, header []byte, body []byte, columnData []int) {
+	marker := out.Len()
+	r.Renderer.Table(out, header, body, columnData)
+	r.lastBlock = newAttributeTarget(out, marker)
+}
+
+// attributeTarget is a rendered block that a following Markdown attribute
+// list may apply to.
+type attributeTarget struct {
+	out *bytes.Buffer
+
+	// The position of the block's opening tag and the end of the block.
+	start, end int
+}
+
+func newAttributeTarget(out *bytes.Buffer, marker int) attributeTarget {
+	start := marker
+	if i := bytes.IndexByte(out.Bytes()[marker:], '<'); i != -1 {
+		start += i
+	}
+	return attributeTarget{out: out, start: start, end: out.Len()}
+}
+
+// insertAttributes adds the attributes to the block's opening tag.
+func (t *attributeTarget) insertAttributes(attrs attributes) {
+	tagEnd := bytes.IndexByte(t.out.Bytes()[t.start:], '>')
+	if tagEnd == -1 {
+		return
+	}
+	tagEnd += t.start
+
+	rest := append([]byte(nil), t.out.Bytes()[tagEnd:]...)
+	t.out.Truncate(tagEnd)
+	attrs.writeTo(t.out)
+	t.out.Write(rest)
+	t.end = t.out.Len()
+}
+
+// DocumentFooter is where Blackfriday inserts its table of contents.
+// Hugo builds the table of contents from the headings, so it's left out.
+func (r *HugoHTMLRenderer) DocumentFooter(out *bytes.Buffer) {
+}
+
 // ListItem adds task list support to the Blackfriday renderer.
 func (r *HugoHTMLRenderer) ListItem(out *bytes.Buffer, text []byte, flags int) {
 	if !r.Config.TaskLists {
@@ -91,8 +362,116 @@ func (r *HugoHTMLRenderer) List(out *bytes.Buffer, text func() bool, flags int)
 // enabling Hugo to customise the rendering experience.
 type HugoMmarkHTMLRenderer struct {
 	cs *ContentSpec
+	*RenderingContext
 	mmark.Renderer
 	Cfg config.Provider
+
+	headingIDs        *HeadingIDs
+	generateHeaderIDs bool
+}
+
+// Header renders a heading with a heading ID that is unique in the page,
+// generated as configured in autoHeadingIDType.
+func (r *HugoMmarkHTMLRenderer) Header(out *bytes.Buffer, text func() bool, level int, id string) {
+	if id == "" && r.generateHeaderIDs {
+		marker := out.Len()
+		if !text() {
+			out.Truncate(marker)
+			return
+		}
+		inner := append([]byte(nil), out.Bytes()[marker:]...)
+		out.Truncate(marker)
+
+		id = r.headingIDs.Generate(headingText(inner))
+		text = func() bool {
+			out.Write(inner)
+			return true
+		}
+	} else if id != "" {
+		id = r.headingIDs.Unique(id)
+	}
+
+	if r.RenderHooks == nil || r.RenderHooks.HeadingRenderer == nil {
+		r.Renderer.Header(out, text, level, id)
+		return
+	}
+
+	// Let Mmark render the heading first, so any attribute list set for
+	// it is applied to the ID, then replace it with the hook's output.
+	var inner []byte
+	marker := out.Len()
+	r.Renderer.Header(out, func() bool {
+		start := out.Len()
+		if !text() {
+			return false
+		}
+		inner = append(inner, out.Bytes()[start:]...)
+		return true
+	}, level, id)
+
+	if out.Len() == marker {
+		return
+	}
+	anchor := headerAnchor(out.Bytes()[marker:])
+	out.Truncate(marker)
+	if out.Len() > 0 {
+		out.WriteByte('\n')
+	}
+
+	err := r.RenderHooks.HeadingRenderer.RenderHeading(out, headingContext{
+		page:      r.Page,
+		level:     level,
+		anchor:    anchor,
+		text:      string(inner),
+		plainText: StripHTML(string(inner)),
+	})
+	if err != nil {
+		jww.ERROR.Printf("Failed to render heading %q in %q: %s", inner, r.DocumentName, err)
+	}
+	out.WriteByte('\n')
+}
+
+// Link renders a link with the link render hook, if provided.
+func (r *HugoMmarkHTMLRenderer) Link(out *bytes.Buffer, link []byte, title []byte, content []byte) {
+	if r.RenderHooks == nil || r.RenderHooks.LinkRenderer == nil {
+		r.Renderer.Link(out, link, title, content)
+		return
+	}
+
+	err := r.RenderHooks.LinkRenderer.RenderLink(out, linkContext{
+		page:        r.Page,
+		destination: string(link),
+		title:       string(title),
+		text:        string(content),
+		plainText:   StripHTML(string(content)),
+	})
+	if err != nil {
+		jww.ERROR.Printf("Failed to render link %q in %q: %s", link, r.DocumentName, err)
+	}
+}
+
+// Image renders an image with the image render hook, if provided.
+func (r *HugoMmarkHTMLRenderer) Image(out *bytes.Buffer, link []byte, title []byte, alt []byte, subfigure bool) {
+	if r.RenderHooks == nil || r.RenderHooks.ImageRenderer == nil {
+		r.Renderer.Image(out, link, title, alt, subfigure)
+		return
+	}
+
+	// Mmark applies any attribute list set for the image when it is
+	// rendered, so it must be rendered to not leak to the next element.
+	var discard bytes.Buffer
+	r.Renderer.Image(&discard, link, title, alt, subfigure)
+
+	err := r.RenderHooks.ImageRenderer.RenderLink(out, linkContext{
+		page:        r.Page,
+		destination: string(link),
+		title:       string(title),
+		text:        template.HTMLEscapeString(string(alt)),
+		plainText:   string(alt),
+	})
+	if err != nil {
+		jww.ERROR.Printf("Failed to render image %q in %q: %s", link, r.DocumentName, err)
+	}
 }
 
 // BlockCode renders a given text as a block of code.
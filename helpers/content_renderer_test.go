@@ -16,6 +16,7 @@ package helpers
 import (
 	"bytes"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -139,3 +140,52 @@ END`, true, `<ul class="task-list">
 		}
 	}
 }
+
+func TestCodeFenceHighlightAttributes(t *testing.T) {
+	assert := require.New(t)
+
+	v := viper.New()
+	v.Set("pygmentsCodeFences", true)
+	v.Set("pygmentsUseClasses", true)
+	v.Set("pygmentsOptions", "linenos=inline")
+
+	c, err := NewContentSpec(v)
+	assert.NoError(err)
+
+	input := "```go {linenos=table,hl_lines=[2],linenostart=40}\nvar a = 1\nvar b = 2\n```\n\n```go\nvar c = 3\n```\n"
+
+	for _, pageFmt := range []string{"markdown", "commonmark"} {
+		ctx := &RenderingContext{Content: []byte(input), PageFmt: pageFmt, Cfg: c.Cfg, Config: c.BlackFriday}
+		result := string(c.RenderBytes(ctx))
+
+		assert.Contains(result, `<table class="lntable">`, pageFmt)
+		assert.Contains(result, "40\n", pageFmt)
+		assert.Contains(result, `<span class="hl">`, pageFmt)
+		// The second code block only gets the site wide options.
+		assert.Contains(result, `<span class="ln">1</span>`, pageFmt)
+		assert.Equal(1, strings.Count(result, "lntable"), pageFmt)
+	}
+}
+
+func TestCodeFenceHighlightAttributesWithoutLanguage(t *testing.T) {
+	assert := require.New(t)
+
+	v := viper.New()
+	v.Set("pygmentsCodeFences", true)
+	v.Set("pygmentsCodeFencesGuessSyntax", true)
+	v.Set("pygmentsUseClasses", true)
+
+	c, err := NewContentSpec(v)
+	assert.NoError(err)
+
+	// Blackfriday passes this on as linenos=table.
+	input := "```{ linenos=table }\nvar a = 1\n```\n"
+
+	for _, pageFmt := range []string{"markdown", "commonmark"} {
+		ctx := &RenderingContext{Content: []byte(input), PageFmt: pageFmt, Cfg: c.Cfg, Config: c.BlackFriday}
+		result := string(c.RenderBytes(ctx))
+
+		assert.Contains(result, `<table class="lntable">`, pageFmt)
+		assert.NotContains(result, "linenos", pageFmt)
+	}
+}
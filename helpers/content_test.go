@@ -89,11 +89,6 @@ func BenchmarkStripHTML(b *testing.B) {
 	}
 }
 
-func TestStripEmptyNav(t *testing.T) {
-	cleaned := stripEmptyNav([]byte("do<nav>\n</nav>\n\nbedobedo"))
-	assert.Equal(t, []byte("dobedobedo"), cleaned)
-}
-
 func TestBytesToHTML(t *testing.T) {
 	assert.Equal(t, template.HTML("dobedobedo"), BytesToHTML([]byte("dobedobedo")))
 }
@@ -378,7 +373,9 @@ func TestGetMarkdownRendererWithTOC(t *testing.T) {
 	ctx := &RenderingContext{RenderTOC: true, Cfg: c.Cfg, Config: c.BlackFriday}
 	ctx.Content = []byte("testContent")
 	actualRenderedMarkdown := c.markdownRender(ctx)
-	expectedRenderedMarkdown := []byte("<nav>\n</nav>\n\n<p>testContent</p>\n")
+	// The table of contents is built from the headings in ctx.TOC, so the
+	// content no longer starts with Blackfriday's (here empty) <nav>.
+	expectedRenderedMarkdown := []byte("<p>testContent</p>\n")
 	if !bytes.Equal(actualRenderedMarkdown, expectedRenderedMarkdown) {
 		t.Errorf("Actual rendered Markdown (%s) did not match expected markdown (%s)", actualRenderedMarkdown, expectedRenderedMarkdown)
 	}
@@ -428,53 +425,136 @@ func TestMmarkRender(t *testing.T) {
 	}
 }
 
-func TestExtractTOCNormalContent(t *testing.T) {
-	content := []byte("<nav>\n<ul>\nTOC<li><a href=\"#")
+func TestCommonmarkRender(t *testing.T) {
+	c := newTestContentSpec()
+	ctx := &RenderingContext{Cfg: c.Cfg, Config: c.BlackFriday}
+	ctx.Content = []byte("testContent")
+	actualRenderedMarkdown := c.commonmarkRender(ctx)
+	expectedRenderedMarkdown := []byte("<p>testContent</p>\n")
+	if !bytes.Equal(actualRenderedMarkdown, expectedRenderedMarkdown) {
+		t.Errorf("Actual rendered Markdown (%s) did not match expected markdown (%s)", actualRenderedMarkdown, expectedRenderedMarkdown)
+	}
+}
+
+func TestCommonmarkRenderSpecCompliance(t *testing.T) {
+	assert := require.New(t)
+	c := newTestContentSpec()
+	ctx := &RenderingContext{Cfg: c.Cfg, Config: c.BlackFriday}
 
-	actualTocLessContent, actualToc := ExtractTOC(content)
-	expectedTocLess := []byte("TOC<li><a href=\"#")
-	expectedToc := []byte("<nav id=\"TableOfContents\">\n<ul>\n")
+	// A list nested with two spaces and a HTML block without a blank line
+	// both differ from Blackfriday.
+	ctx.Content = []byte("- a\n  - b\n\n<div>\n*c*\n</div>\n\n- [x] done\n")
+	result := string(c.commonmarkRender(ctx))
 
-	if !bytes.Equal(actualTocLessContent, expectedTocLess) {
-		t.Errorf("Actual tocless (%s) did not equal expected (%s) tocless content", actualTocLessContent, expectedTocLess)
-	}
+	assert.Contains(result, "<li>a\n<ul>\n<li>b</li>\n</ul>\n</li>")
+	assert.Contains(result, "<div>\n*c*\n</div>")
+	assert.Contains(result, `<li><input type="checkbox" checked disabled class="task-list-item"> done</li>`)
+}
 
-	if !bytes.Equal(actualToc, expectedToc) {
-		t.Errorf("Actual toc (%s) did not equal expected (%s) toc content", actualToc, expectedToc)
-	}
+func TestCommonmarkRenderWithTOC(t *testing.T) {
+	assert := require.New(t)
+	c := newTestContentSpec()
+	ctx := &RenderingContext{RenderTOC: true, PageFmt: "commonmark", Cfg: c.Cfg, Config: c.BlackFriday}
+	ctx.Content = []byte("## First\n\n### Sub *one*\n\n## First\n")
+
+	content := c.RenderBytes(ctx)
+
+	assert.Equal(template.HTML(`<nav id="TableOfContents">
+<ul>
+<li>
+<ul>
+<li><a href="#first">First</a>
+<ul>
+<li><a href="#sub-one">Sub <em>one</em></a></li>
+</ul></li>
+<li><a href="#first-1">First</a></li>
+</ul></li>
+</ul>
+</nav>`), ctx.TOC.ToHTML())
+	assert.Equal("<h2 id=\"first\">First</h2>\n<h3 id=\"sub-one\">Sub <em>one</em></h3>\n<h2 id=\"first-1\">First</h2>\n", string(content))
 }
 
-func TestExtractTOCGreaterThanSeventy(t *testing.T) {
-	content := []byte("<nav>\n<ul>\nTOC This is a very long content which will definitely be greater than seventy, I promise you that.<li><a href=\"#")
+func TestMarkdownRenderWithTOC(t *testing.T) {
+	assert := require.New(t)
+	c := newTestContentSpec()
+	ctx := &RenderingContext{RenderTOC: true, PageFmt: "markdown", Cfg: c.Cfg, Config: c.BlackFriday}
+	ctx.Content = []byte("# Title\n\n## First\n\n### Sub *one*\n\n## First\n")
+
+	content := c.RenderBytes(ctx)
+
+	assert.Equal(template.HTML(`<nav id="TableOfContents">
+<ul>
+<li><a href="#title">Title</a>
+<ul>
+<li><a href="#first">First</a>
+<ul>
+<li><a href="#sub-one">Sub <em>one</em></a></li>
+</ul></li>
+<li><a href="#first-1">First</a></li>
+</ul></li>
+</ul>
+</nav>`), ctx.TOC.ToHTML())
+	assert.NotContains(string(content), "<nav>")
+	assert.Contains(string(content), `<h2 id="first-1">First</h2>`)
+}
+
+func TestMarkdownAttributes(t *testing.T) {
+	assert := require.New(t)
+	c := newTestContentSpec()
 
-	actualTocLessContent, actualToc := ExtractTOC(content)
-	//Because the start of Toc is greater than 70+startpoint of <li> content and empty TOC will be returned
-	expectedToc := []byte("")
+	content := []byte(`## Heading {#custom-id .title data-x=1}
 
-	if !bytes.Equal(actualTocLessContent, content) {
-		t.Errorf("Actual tocless (%s) did not equal expected (%s) tocless content", actualTocLessContent, content)
-	}
+## Other {.sub}
 
-	if !bytes.Equal(actualToc, expectedToc) {
-		t.Errorf("Actual toc (%s) did not equal expected (%s) toc content", actualToc, expectedToc)
-	}
-}
+A paragraph.
+{.note}
 
-func TestExtractNoTOC(t *testing.T) {
-	content := []byte("TOC")
+| a | b |
+|---|---|
+| 1 | 2 |
+{.table}
 
-	actualTocLessContent, actualToc := ExtractTOC(content)
-	expectedToc := []byte("")
+> A quote.
 
-	if !bytes.Equal(actualTocLessContent, content) {
-		t.Errorf("Actual tocless (%s) did not equal expected (%s) tocless content", actualTocLessContent, content)
-	}
+{.quote}
 
-	if !bytes.Equal(actualToc, expectedToc) {
-		t.Errorf("Actual toc (%s) did not equal expected (%s) toc content", actualToc, expectedToc)
+Text {not attributes}
+`)
+
+	for _, pageFmt := range []string{"markdown", "commonmark"} {
+		ctx := &RenderingContext{RenderTOC: true, PageFmt: pageFmt, Content: content, Cfg: c.Cfg, Config: c.BlackFriday}
+		result := string(c.RenderBytes(ctx))
+
+		assert.Contains(result, `<h2 id="custom-id" class="title" data-x="1">Heading</h2>`, pageFmt)
+		assert.Regexp(`<h2[^>]* id="other"[^>]*>Other</h2>`, result, pageFmt)
+		assert.Regexp(`<h2[^>]* class="sub"[^>]*>Other</h2>`, result, pageFmt)
+		assert.Contains(result, `<p class="note">A paragraph.</p>`, pageFmt)
+		assert.Contains(result, `<table class="table">`, pageFmt)
+		assert.NotContains(result, `{.table}`, pageFmt)
+		assert.Contains(result, `<blockquote class="quote">`, pageFmt)
+		assert.Contains(result, `<p>Text {not attributes}</p>`, pageFmt)
+
+		assert.Len(ctx.TOC.Headings, 2, pageFmt)
+		assert.Equal("custom-id", ctx.TOC.Headings[0].ID, pageFmt)
+		assert.Equal(map[string]string{"class": "title", "data-x": "1"}, ctx.TOC.Headings[0].Attributes, pageFmt)
+		assert.Equal("other", ctx.TOC.Headings[1].ID, pageFmt)
+		assert.Equal(template.HTML("Other"), ctx.TOC.Headings[1].Text, pageFmt)
 	}
 }
 
+func TestCommonmarkRenderDocumentIDs(t *testing.T) {
+	assert := require.New(t)
+	c := newTestContentSpec()
+	ctx := &RenderingContext{DocumentID: "doc", Cfg: c.Cfg, Config: c.BlackFriday}
+	ctx.Config.PlainIDAnchors = false
+	ctx.Content = []byte("# Title\n\nText[^1]\n\n[^1]: Note.\n")
+
+	result := string(c.commonmarkRender(ctx))
+
+	assert.Contains(result, `<h1 id="title:doc">Title</h1>`)
+	assert.Contains(result, `href="#doc:fn:1"`)
+}
+
 var totalWordsBenchmarkString = strings.Repeat("Hugo Rocks ", 200)
 
 func TestTotalWords(t *testing.T) {
@@ -0,0 +1,168 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+
+	"github.com/gohugoio/hugo/common/maps"
+	"github.com/mitchellh/mapstructure"
+)
+
+// TableOfContentsConfig holds the configuration for the table of contents.
+type TableOfContentsConfig struct {
+	// Headings with a level outside of StartLevel and EndLevel
+	// (both inclusive) are left out of the table of contents.
+	StartLevel int
+	EndLevel   int
+}
+
+func newTableOfContentsConfig(config map[string]interface{}) (TableOfContentsConfig, error) {
+	defaultParam := map[string]interface{}{
+		"startLevel": 1,
+		"endLevel":   6,
+	}
+
+	maps.ToLower(defaultParam)
+
+	siteConfig := make(map[string]interface{})
+
+	for k, v := range defaultParam {
+		siteConfig[k] = v
+	}
+
+	for k, v := range config {
+		siteConfig[k] = v
+	}
+
+	var conf TableOfContentsConfig
+	if err := mapstructure.WeakDecode(siteConfig, &conf); err != nil {
+		return conf, fmt.Errorf("failed to decode tableOfContents config: %s", err)
+	}
+
+	if conf.StartLevel < 1 || conf.EndLevel > 6 || conf.StartLevel > conf.EndLevel {
+		return conf, fmt.Errorf("invalid tableOfContents config: startLevel (%d) and endLevel (%d) must be in the range 1 to 6, with startLevel <= endLevel", conf.StartLevel, conf.EndLevel)
+	}
+
+	return conf, nil
+}
+
+// TableOfContents holds the headings of a rendered document as a tree.
+type TableOfContents struct {
+	// The top level headings.
+	Headings TOCHeadings
+
+	cfg TableOfContentsConfig
+}
+
+// TOCHeading is a heading in the table of contents.
+// A heading with an empty ID is a placeholder for a skipped heading level,
+// e.g. for the missing level 3 when a level 4 heading follows a level 2 heading.
+type TOCHeading struct {
+	ID    string        `json:"id"`
+	Text  template.HTML `json:"text"`
+	Level int           `json:"level"`
+
+	// The attributes other than the ID set with a Markdown attribute list,
+	// e.g. {.class}.
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	Children TOCHeadings `json:"children,omitempty"`
+}
+
+// TOCHeadings is a list of table of contents headings.
+type TOCHeadings []*TOCHeading
+
+// NewTableOfContents creates a new, empty table of contents that will include
+// the headings within the levels configured in cfg.
+func NewTableOfContents(cfg TableOfContentsConfig) *TableOfContents {
+	return &TableOfContents{cfg: cfg}
+}
+
+// AddHeading adds a heading to the table of contents. The headings must
+// be added in document order. Headings outside of the configured levels
+// are ignored. The attributes may be nil.
+func (toc *TableOfContents) AddHeading(level int, id, text string, attributes map[string]string) {
+	if level < toc.cfg.StartLevel || level > toc.cfg.EndLevel {
+		return
+	}
+
+	headings := &toc.Headings
+	for l := toc.cfg.StartLevel; l < level; l++ {
+		if len(*headings) == 0 {
+			*headings = append(*headings, &TOCHeading{Level: l})
+		}
+		headings = &(*headings)[len(*headings)-1].Children
+	}
+
+	*headings = append(*headings, &TOCHeading{ID: id, Text: template.HTML(text), Level: level, Attributes: attributes})
+}
+
+// IsZero returns whether the table of contents has no headings.
+func (toc *TableOfContents) IsZero() bool {
+	return toc == nil || len(toc.Headings) == 0
+}
+
+// ToHTML renders the table of contents as a nested HTML list wrapped in
+// a nav element. It returns an empty string if there are no headings.
+func (toc *TableOfContents) ToHTML() template.HTML {
+	if toc.IsZero() {
+		return ""
+	}
+
+	var b bytes.Buffer
+	b.WriteString("<nav id=\"TableOfContents\">\n")
+	writeTOCHeadings(&b, toc.Headings)
+	b.WriteString("\n</nav>")
+
+	return template.HTML(b.String())
+}
+
+func writeTOCHeadings(b *bytes.Buffer, headings TOCHeadings) {
+	b.WriteString("<ul>\n")
+	for _, h := range headings {
+		b.WriteString("<li>")
+		if h.ID != "" || h.Text != "" {
+			b.WriteString("<a href=\"#")
+			b.WriteString(h.ID)
+			b.WriteString("\">")
+			b.WriteString(string(h.Text))
+			b.WriteString("</a>")
+		}
+		if len(h.Children) > 0 {
+			b.WriteByte('\n')
+			writeTOCHeadings(b, h.Children)
+		}
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</ul>")
+}
+
+// JSON returns the headings as a JSON array, e.g. for client side navigation.
+func (toc *TableOfContents) JSON() (template.JS, error) {
+	headings := TOCHeadings{}
+	if toc != nil && toc.Headings != nil {
+		headings = toc.Headings
+	}
+
+	b, err := json.Marshal(headings)
+	if err != nil {
+		return "", err
+	}
+
+	return template.JS(b), nil
+}
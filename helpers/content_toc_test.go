@@ -0,0 +1,113 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableOfContents(t *testing.T) {
+	assert := require.New(t)
+
+	toc := NewTableOfContents(TableOfContentsConfig{StartLevel: 2, EndLevel: 3})
+	toc.AddHeading(1, "title", "Title", nil)
+	toc.AddHeading(2, "a", "A", nil)
+	toc.AddHeading(3, "aa", "AA", nil)
+	toc.AddHeading(4, "aaa", "AAA", nil)
+	toc.AddHeading(2, "b", "<em>B</em>", nil)
+
+	assert.Len(toc.Headings, 2)
+	assert.Equal("a", toc.Headings[0].ID)
+	assert.Equal(2, toc.Headings[0].Level)
+	assert.Len(toc.Headings[0].Children, 1)
+	assert.Equal("aa", toc.Headings[0].Children[0].ID)
+	assert.Len(toc.Headings[0].Children[0].Children, 0)
+	assert.Equal(template.HTML("<em>B</em>"), toc.Headings[1].Text)
+
+	assert.Equal(template.HTML(`<nav id="TableOfContents">
+<ul>
+<li><a href="#a">A</a>
+<ul>
+<li><a href="#aa">AA</a></li>
+</ul></li>
+<li><a href="#b"><em>B</em></a></li>
+</ul>
+</nav>`), toc.ToHTML())
+
+	json, err := toc.JSON()
+	assert.NoError(err)
+	assert.Equal(template.JS(`[{"id":"a","text":"A","level":2,"children":[{"id":"aa","text":"AA","level":3}]},{"id":"b","text":"\u003cem\u003eB\u003c/em\u003e","level":2}]`), json)
+}
+
+func TestTableOfContentsSkippedLevels(t *testing.T) {
+	assert := require.New(t)
+
+	toc := NewTableOfContents(TableOfContentsConfig{StartLevel: 1, EndLevel: 6})
+	toc.AddHeading(3, "a", "A", nil)
+	toc.AddHeading(1, "b", "B", nil)
+
+	assert.Len(toc.Headings, 2)
+	assert.Equal("", toc.Headings[0].ID)
+	assert.Equal(1, toc.Headings[0].Level)
+	assert.Equal(2, toc.Headings[0].Children[0].Level)
+	assert.Equal("a", toc.Headings[0].Children[0].Children[0].ID)
+
+	assert.Equal(template.HTML(`<nav id="TableOfContents">
+<ul>
+<li>
+<ul>
+<li>
+<ul>
+<li><a href="#a">A</a></li>
+</ul></li>
+</ul></li>
+<li><a href="#b">B</a></li>
+</ul>
+</nav>`), toc.ToHTML())
+}
+
+func TestTableOfContentsEmpty(t *testing.T) {
+	assert := require.New(t)
+
+	toc := NewTableOfContents(TableOfContentsConfig{StartLevel: 2, EndLevel: 3})
+	toc.AddHeading(1, "title", "Title", nil)
+
+	assert.True(toc.IsZero())
+	assert.Equal(template.HTML(""), toc.ToHTML())
+
+	json, err := toc.JSON()
+	assert.NoError(err)
+	assert.Equal(template.JS("[]"), json)
+}
+
+func TestNewTableOfContentsConfig(t *testing.T) {
+	assert := require.New(t)
+
+	conf, err := newTableOfContentsConfig(nil)
+	assert.NoError(err)
+	assert.Equal(TableOfContentsConfig{StartLevel: 1, EndLevel: 6}, conf)
+
+	conf, err = newTableOfContentsConfig(map[string]interface{}{"startlevel": 2, "endlevel": "3"})
+	assert.NoError(err)
+	assert.Equal(TableOfContentsConfig{StartLevel: 2, EndLevel: 3}, conf)
+
+	_, err = newTableOfContentsConfig(map[string]interface{}{"startlevel": 4, "endlevel": 3})
+	assert.Error(err)
+
+	_, err = newTableOfContentsConfig(map[string]interface{}{"endlevel": 7})
+	assert.Error(err)
+}
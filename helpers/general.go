@@ -80,6 +80,8 @@ func GuessType(in string) string {
 		return "markdown"
 	case "asciidoc", "adoc", "ad":
 		return "asciidoc"
+	case "commonmark":
+		return "commonmark"
 	case "mmark":
 		return "mmark"
 	case "rst":
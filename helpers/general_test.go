@@ -39,6 +39,7 @@ func TestGuessType(t *testing.T) {
 		{"pandoc", "pandoc"},
 		{"pdc", "pandoc"},
 		{"mmark", "mmark"},
+		{"commonmark", "commonmark"},
 		{"html", "html"},
 		{"htm", "html"},
 		{"org", "org"},
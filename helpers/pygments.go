@@ -255,6 +255,120 @@ func parseOptions(defaults map[string]string, in string) (map[string]string, err
 	return opts, nil
 }
 
+// parseCodeFenceInfo splits the info string of a fenced code block, e.g.
+// go {linenos=table,hl_lines=[3,"7-9"],linenostart=40}, into the language and
+// the highlight options in the format used by pygmentsOptions.
+// Blackfriday strips the braces from an info string without a language,
+// e.g. { linenos=true } arrives as linenos=true, so a first token
+// containing "=" starts the options.
+func parseCodeFenceInfo(info string) (lang, opts string, err error) {
+	attrStart := strings.Index(info, "{")
+	if attrStart == -1 {
+		fields := strings.Fields(info)
+		if len(fields) == 0 {
+			return
+		}
+		if strings.Contains(fields[0], "=") {
+			opts, err = parseHighlightAttributes(strings.TrimSpace(info))
+			return
+		}
+		lang = fields[0]
+		return
+	}
+
+	if fields := strings.Fields(info[:attrStart]); len(fields) > 0 {
+		lang = fields[0]
+	}
+
+	attrs := strings.TrimSpace(info[attrStart:])
+	if !strings.HasSuffix(attrs, "}") {
+		return lang, "", fmt.Errorf("code block attributes %q must end with }", attrs)
+	}
+
+	opts, err = parseHighlightAttributes(attrs[1 : len(attrs)-1])
+
+	return
+}
+
+// parseHighlightAttributes parses a list of key=value pairs separated by
+// commas or spaces, where a value may be a quoted string or a list of
+// numbers and quoted strings, e.g. linenos=table,hl_lines=[3,"7-9"].
+// The list values are joined with spaces, e.g. hl_lines=3 7-9.
+func parseHighlightAttributes(s string) (string, error) {
+	var (
+		pos  int
+		opts []string
+	)
+
+	skipSeparators := func() {
+		for pos < len(s) && (s[pos] == ',' || s[pos] == ' ' || s[pos] == '\t') {
+			pos++
+		}
+	}
+
+	readValue := func(stop string) (string, error) {
+		if pos < len(s) && s[pos] == '"' {
+			end := strings.IndexByte(s[pos+1:], '"')
+			if end == -1 {
+				return "", fmt.Errorf("unterminated string in %q", s)
+			}
+			v := s[pos+1 : pos+1+end]
+			pos += end + 2
+			return v, nil
+		}
+		start := pos
+		for pos < len(s) && !strings.ContainsRune(stop, rune(s[pos])) {
+			pos++
+		}
+		return strings.TrimSpace(s[start:pos]), nil
+	}
+
+	for skipSeparators(); pos < len(s); skipSeparators() {
+		eq := strings.IndexByte(s[pos:], '=')
+		if eq == -1 {
+			return "", fmt.Errorf("missing value for %q", strings.TrimSpace(s[pos:]))
+		}
+		key := strings.ToLower(strings.TrimSpace(s[pos : pos+eq]))
+		if !pygmentsKeywords[key] {
+			return "", fmt.Errorf("invalid highlight option: %s", key)
+		}
+		pos += eq + 1
+
+		var value string
+
+		if pos < len(s) && s[pos] == '[' {
+			pos++
+			var values []string
+			for {
+				skipSeparators()
+				if pos >= len(s) {
+					return "", fmt.Errorf("unterminated list for %q", key)
+				}
+				if s[pos] == ']' {
+					pos++
+					break
+				}
+				v, err := readValue(", \t]")
+				if err != nil {
+					return "", err
+				}
+				values = append(values, v)
+			}
+			value = strings.Join(values, " ")
+		} else {
+			v, err := readValue(", \t")
+			if err != nil {
+				return "", err
+			}
+			value = v
+		}
+
+		opts = append(opts, key+"="+value)
+	}
+
+	return strings.Join(opts, ","), nil
+}
+
 func createOptionsString(options map[string]string) string {
 	var keys []string
 	for k := range options {
@@ -298,3 +298,36 @@ func GetTitleFunc(style string) func(s string) string {
 		}
 	}
 }
+
+func TestParseCodeFenceInfo(t *testing.T) {
+	assert := require.New(t)
+
+	for i, this := range []struct {
+		in     string
+		lang   string
+		opts   string
+		expErr bool
+	}{
+		{"", "", "", false},
+		{"go", "go", "", false},
+		{"go {linenos=table,hl_lines=[3,\"7-9\"],linenostart=40}", "go", "linenos=table,hl_lines=3 7-9,linenostart=40", false},
+		{"go{linenos=inline hl_lines=[2 4]}", "go", "linenos=inline,hl_lines=2 4", false},
+		{"{ linenos=true }", "", "linenos=true", false},
+		{"linenos=true", "", "linenos=true", false},
+		{"linenos=table hl_lines=[2]", "", "linenos=table,hl_lines=2", false},
+		{"bash {style=\"monokai\"}", "bash", "style=monokai", false},
+		{"go {foo=bar}", "go", "", true},
+		{"go {linenos=table", "go", "", true},
+		{"go {hl_lines=[1,2}", "go", "", true},
+		{"go {linenos}", "go", "", true},
+	} {
+		lang, opts, err := parseCodeFenceInfo(this.in)
+		if this.expErr {
+			assert.Error(err, fmt.Sprintf("[%d] %s", i, this.in))
+			continue
+		}
+		assert.NoError(err, fmt.Sprintf("[%d] %s", i, this.in))
+		assert.Equal(this.lang, lang, fmt.Sprintf("[%d] %s", i, this.in))
+		assert.Equal(this.opts, opts, fmt.Sprintf("[%d] %s", i, this.in))
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const cascadeTestConfig = `
+baseURL = "https://example.org"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.nn]
+weight = 2
+`
+
+func newCascadeTestBuilder(t *testing.T) *sitesBuilder {
+	b := newTestSitesBuilder(t).WithConfigFile("toml", cascadeTestConfig)
+
+	b.WithTemplatesAdded(
+		"_default/single.html", `{{ .Kind }}|{{ .Title }}|{{ .Params.banner }}|{{ .Type }}|{{ .Layout }}`,
+		"_default/list.html", `{{ .Kind }}|{{ .Title }}|{{ .Params.banner }}|{{ .Type }}|{{ .Layout }}`,
+		"_default/special.html", `SPECIAL|{{ .Title }}|{{ .Params.banner }}`,
+	)
+
+	b.WithContent(
+		"_index.md", `---
+title: Home
+cascade:
+  banner: home.jpg
+---
+`,
+		"_index.nn.md", `---
+title: Heim
+cascade:
+  banner: heim.jpg
+---
+`,
+		"blog/_index.md", `---
+title: Blog
+cascade:
+  banner: blog.jpg
+  layout: special
+---
+`,
+		"blog/p1.md", `---
+title: P1
+---
+`,
+		"blog/p2.md", `---
+title: P2
+banner: p2.jpg
+---
+`,
+		"blog/sub/p3.md", `---
+title: P3
+---
+`,
+		"docs/d1.md", `---
+title: D1
+---
+`,
+		"docs/d1.nn.md", `---
+title: D1 NN
+---
+`,
+	)
+
+	return b
+}
+
+func TestCascade(t *testing.T) {
+	t.Parallel()
+
+	b := newCascadeTestBuilder(t)
+	b.Build(BuildCfg{})
+
+	// The home page's own cascade applies to its descendants only.
+	b.AssertFileContent("public/index.html", "home|Home||page|")
+	b.AssertFileContent("public/docs/index.html", "section|Docs|home.jpg|docs|")
+	b.AssertFileContent("public/docs/d1/index.html", "page|D1|home.jpg|docs|")
+
+	// The closest cascade wins, the page's own front matter above all.
+	b.AssertFileContent("public/blog/p1/index.html", "SPECIAL|P1|blog.jpg")
+	b.AssertFileContent("public/blog/p2/index.html", "SPECIAL|P2|p2.jpg")
+	b.AssertFileContent("public/blog/sub/p3/index.html", "SPECIAL|P3|blog.jpg")
+
+	// Each language has its own cascade.
+	b.AssertFileContent("public/nn/docs/d1/index.html", "page|D1 NN|heim.jpg|docs|")
+}
+
+func TestCascadeRebuild(t *testing.T) {
+	b := newCascadeTestBuilder(t).Running()
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/blog/p1/index.html", "SPECIAL|P1|blog.jpg")
+
+	b.EditFiles("content/blog/_index.md", `---
+title: Blog
+cascade:
+  banner: blog2.jpg
+---
+`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/blog/p1/index.html", "page|P1|blog2.jpg|blog|")
+	b.AssertFileContent("public/blog/p2/index.html", "page|P2|p2.jpg|blog|")
+
+	b.EditFiles("content/blog/_index.md", `---
+title: Blog
+---
+`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/blog/p1/index.html", "page|P1|home.jpg|blog|")
+}
+
+func TestCascadeDraft(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithTemplatesAdded(
+		"_default/single.html", `{{ .Title }}`,
+		"index.html", `{{ range .Site.RegularPages }}{{ .Title }}|{{ end }}`,
+	)
+
+	b.WithContent(
+		"drafts/_index.md", `---
+title: Drafts
+cascade:
+  draft: true
+---
+`,
+		"drafts/d1.md", `---
+title: D1
+---
+`,
+		"drafts/d2.md", `---
+title: D2
+draft: false
+---
+`,
+		"future/_index.md", `---
+title: Future
+cascade:
+  publishDate: 2099-01-01
+---
+`,
+		"future/f1.md", `---
+title: F1
+---
+`,
+		"blog/p1.md", `---
+title: P1
+---
+`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "D2|P1|")
+	b.AssertFileContent("public/drafts/d2/index.html", "D2")
+	b.AssertFileContent("public/blog/p1/index.html", "P1")
+	assert.False(b.CheckExists("public/drafts/d1/index.html"))
+	assert.False(b.CheckExists("public/future/f1/index.html"))
+}
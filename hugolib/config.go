@@ -619,8 +619,11 @@ func loadDefaultSettingsFor(v *viper.Viper) error {
 	v.SetDefault("paginatePath", "page")
 	v.SetDefault("summaryLength", 70)
 	v.SetDefault("blackfriday", c.BlackFriday)
+	v.SetDefault("defaultMarkdownHandler", "blackfriday")
+	v.SetDefault("autoHeadingIDType", "blackfriday")
 	v.SetDefault("rssLimit", -1)
 	v.SetDefault("sectionPagesMenu", "")
+	v.SetDefault("sectionPagesMenuTree", false)
 	v.SetDefault("disablePathToLower", false)
 	v.SetDefault("hasCJKLanguage", false)
 	v.SetDefault("enableEmoji", false)
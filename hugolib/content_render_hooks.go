@@ -0,0 +1,85 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/tpl"
+)
+
+// The render hooks live in this folder below layouts,
+// e.g. layouts/_default/_markup/render-link.html.
+const renderHooksPrefix = "_default/_markup/"
+
+const (
+	renderHookLink    = "render-link.html"
+	renderHookImage   = "render-image.html"
+	renderHookHeading = "render-heading.html"
+)
+
+// hookRenderer renders a Markdown element with a user provided template.
+type hookRenderer struct {
+	templ tpl.Template
+}
+
+func (r hookRenderer) RenderLink(w io.Writer, ctx helpers.LinkContext) error {
+	return r.templ.Execute(w, ctx)
+}
+
+func (r hookRenderer) RenderHeading(w io.Writer, ctx helpers.HeadingContext) error {
+	return r.templ.Execute(w, ctx)
+}
+
+// renderHooks returns the render hooks found in the layouts, nil if none.
+// They are looked up once per build.
+func (s *Site) renderHooks() *helpers.RenderHooks {
+	v, _ := s.init.renderHooks.Do()
+	return v.(*helpers.RenderHooks)
+}
+
+func (s *Site) lookupRenderHooks() *helpers.RenderHooks {
+	var (
+		hooks helpers.RenderHooks
+		found bool
+	)
+
+	if templ, ok := s.Tmpl.Lookup(renderHooksPrefix + renderHookLink); ok {
+		hooks.LinkRenderer = hookRenderer{templ: templ}
+		found = true
+	}
+
+	if templ, ok := s.Tmpl.Lookup(renderHooksPrefix + renderHookImage); ok {
+		hooks.ImageRenderer = hookRenderer{templ: templ}
+		found = true
+	}
+
+	if templ, ok := s.Tmpl.Lookup(renderHooksPrefix + renderHookHeading); ok {
+		hooks.HeadingRenderer = hookRenderer{templ: templ}
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+
+	return &hooks
+}
+
+func isRenderHookTemplate(filename string) bool {
+	return strings.Contains(filepath.ToSlash(filename), "/_markup/render-")
+}
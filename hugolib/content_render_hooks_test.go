@@ -0,0 +1,114 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import "testing"
+
+func TestRenderHooks(t *testing.T) {
+	t.Parallel()
+
+	for _, markup := range []string{"markdown", "commonmark"} {
+		markup := markup
+		t.Run(markup, func(t *testing.T) {
+			b := newTestSitesBuilder(t).WithSimpleConfigFile()
+			b.WithTemplatesAdded(
+				"_default/single.html", `{{ .Content }}`,
+				"_default/_markup/render-link.html", `<a href="{{ .Destination | safeURL }}"{{ if hasPrefix .Destination "http" }} rel="noopener"{{ end }}>{{ .Text }}</a>`,
+				"_default/_markup/render-image.html", `IMAGE: {{ .Destination }}|{{ .Text }}|{{ .Title }}|{{ .Page.Title }}`,
+				"_default/_markup/render-heading.html", `HEADING: {{ .Level }}|{{ .Anchor }}|{{ .Text }}|{{ .PlainText }}{{ with .Attributes }}|{{ .class }}{{ end }}`,
+			)
+			b.WithContent("p1.md", `---
+title: P1
+markup: `+markup+`
+---
+
+## The *Heading*
+
+## Attributes {#attrs .big}
+
+[External](https://gohugo.io/) and [internal](/docs/).
+
+![The Alt](sunset.jpg "The Title")
+`)
+
+			b.Build(BuildCfg{})
+
+			b.AssertFileContent("public/p1/index.html",
+				`HEADING: 2|the-heading|The <em>Heading</em>|The Heading`,
+				`HEADING: 2|attrs|Attributes|Attributes|big`,
+				`<a href="https://gohugo.io/" rel="noopener">External</a>`,
+				`<a href="/docs/">internal</a>`,
+				`IMAGE: sunset.jpg|The Alt|The Title|P1`,
+			)
+		})
+	}
+}
+
+func TestRenderHooksMmark(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+	b.WithTemplatesAdded(
+		"_default/single.html", `{{ .Content }}`,
+		"_default/_markup/render-link.html", `LINK: {{ .Destination }}|{{ .Text }}`,
+		"_default/_markup/render-image.html", `IMAGE: {{ .Destination }}|{{ .Text }}|{{ .Title }}`,
+		"_default/_markup/render-heading.html", `HEADING: {{ .Level }}|{{ .Anchor }}|{{ .Text }}`,
+	)
+	b.WithContent("p1.mmark", `---
+title: P1
+---
+
+## The *Heading*
+
+[Internal](/docs/).
+
+![The Alt](sunset.jpg "The Title")
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/p1/index.html",
+		`HEADING: 2|the-heading|The <em>Heading</em>`,
+		`LINK: /docs/|Internal`,
+		`IMAGE: sunset.jpg|The Alt|The Title`,
+	)
+}
+
+func TestRenderHooksRebuild(t *testing.T) {
+	b := newTestSitesBuilder(t).Running().WithSimpleConfigFile()
+	b.WithTemplatesAdded(
+		"_default/single.html", `{{ .Content }}`,
+		"_default/_markup/render-link.html", `LINK1: {{ .Destination }}`,
+	)
+	b.WithContent("p1.md", `---
+title: P1
+---
+
+## Heading
+
+[Link](/p2/)
+`)
+
+	b.Build(BuildCfg{})
+	b.AssertFileContent("public/p1/index.html", `LINK1: /p2/`, `<h2 id="heading">Heading</h2>`)
+
+	b.EditFiles("layouts/_default/_markup/render-link.html", `LINK2: {{ .Destination }}`)
+	b.Build(BuildCfg{})
+	b.AssertFileContent("public/p1/index.html", `LINK2: /p2/`)
+
+	// The hooks are looked up again when the templates change.
+	b.EditFiles("layouts/_default/_markup/render-heading.html", `HEADING: {{ .Anchor }}`)
+	b.Build(BuildCfg{})
+	b.AssertFileContent("public/p1/index.html", `LINK2: /p2/`, `HEADING: heading`)
+}
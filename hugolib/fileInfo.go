@@ -72,11 +72,19 @@ func (fi *fileInfo) isOwner() bool {
 }
 
 func IsContentFile(filename string) bool {
-	return contentFileExtensionsSet[strings.TrimPrefix(helpers.Ext(filename), ".")]
+	return isContentExtFunc(isDefaultContentExt).isContentFile(filename)
 }
 
-func (fi *fileInfo) isContentFile() bool {
-	return contentFileExtensionsSet[fi.Ext()]
+// isContentExtFunc reports whether files with the given extension, without
+// the dot, are content files.
+type isContentExtFunc func(ext string) bool
+
+func isDefaultContentExt(ext string) bool {
+	return contentFileExtensionsSet[ext]
+}
+
+func (f isContentExtFunc) isContentFile(filename string) bool {
+	return f(strings.TrimPrefix(helpers.Ext(filename), "."))
 }
 
 func newFileInfo(sp *source.SourceSpec, baseDir, filename string, fi pathLangFileFi, tp bundleDirType) *fileInfo {
@@ -107,8 +115,8 @@ const (
 
 // Returns the given file's name's bundle type and whether it is a content
 // file or not.
-func classifyBundledFile(name string) (bundleDirType, bool) {
-	if !IsContentFile(name) {
+func (f isContentExtFunc) classifyBundledFile(name string) (bundleDirType, bool) {
+	if !f.isContentFile(name) {
 		return bundleNot, false
 	}
 	if strings.HasPrefix(name, "_index.") {
@@ -78,6 +78,9 @@ type HugoSites struct {
 	// Keeps track of bundle directories and symlinks to enable partial rebuilding.
 	ContentChanges *contentChangeMap
 
+	// The links between the pages in all sites.
+	linkGraph *linkGraph
+
 	init *hugoSitesInit
 
 	*fatalErrorHandler
@@ -230,6 +233,17 @@ func (h *HugoSites) langSite() map[string]*Site {
 	return m
 }
 
+// isContentExt reports whether files with the given extension, without the
+// dot, are content files in any of the sites.
+func (h *HugoSites) isContentExt(ext string) bool {
+	for _, s := range h.Sites {
+		if s.isContentExt(ext) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetContentPage finds a Page with content given the absolute filename.
 // Returns nil if none found.
 func (h *HugoSites) GetContentPage(filename string) page.Page {
@@ -319,7 +333,7 @@ func newHugoSites(cfg deps.DepsCfg, sites ...*Site) (*HugoSites, error) {
 	// Only needed in server mode.
 	// TODO(bep) clean up the running vs watching terms
 	if cfg.Running {
-		contentChangeTracker = &contentChangeMap{pathSpec: h.PathSpec, symContent: make(map[string]map[string]bool)}
+		contentChangeTracker = &contentChangeMap{pathSpec: h.PathSpec, isContentExt: h.isContentExt, symContent: make(map[string]map[string]bool)}
 		h.ContentChanges = contentChangeTracker
 	}
 
@@ -562,6 +576,9 @@ type BuildCfg struct {
 
 	// Recently visited URLs. This is used for partial re-rendering.
 	RecentlyVisited map[string]bool
+
+	// Only render the pages whose backlinks changed.
+	backlinksReRender bool
 }
 
 // shouldRender is used in the Fast Render Mode to determine if we need to re-render
@@ -570,10 +587,13 @@ type BuildCfg struct {
 // For regular builds, this will allways return true.
 // TODO(bep) rename/work this.
 func (cfg *BuildCfg) shouldRender(p *pageState) bool {
-	if !p.render {
+	if !p.render || p.m.noRender() {
 		return false
 	}
-	if p.forceRender {
+	if cfg.backlinksReRender {
+		return p.backlinksChanged
+	}
+	if p.forceRender || p.backlinksChanged {
 		return true
 	}
 
@@ -623,6 +643,8 @@ func (h *HugoSites) renderCrossSitesArtifacts() error {
 func (h *HugoSites) createMissingPages() error {
 
 	for _, s := range h.Sites {
+		numPages := len(s.workAllPages)
+
 		if s.isEnabled(page.KindHome) {
 			// home pages
 			homes := s.findWorkPagesByKind(page.KindHome)
@@ -717,7 +739,7 @@ func (h *HugoSites) createMissingPages() error {
 								panic("no info found")
 							}
 
-							n := s.newTaxonomyPage(info.term, info.plural, info.termKey)
+							n := s.newTaxonomyPage(info.title(), info.plural, info.termKey)
 							info.TransferValues(n)
 							s.workAllPages = append(s.workAllPages, n)
 						}
@@ -725,6 +747,15 @@ func (h *HugoSites) createMissingPages() error {
 				}
 			}
 		}
+
+		if len(taxonomies) > 0 {
+			s.linkTaxonomyTerms()
+		}
+
+		// Apply the front matter cascade to the pages created above.
+		if err := s.applyCascade(s.workAllPages[numPages:]); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -738,6 +769,13 @@ func (h *HugoSites) removePageByFilename(filename string) {
 
 func (h *HugoSites) createPageCollections() error {
 	for _, s := range h.Sites {
+		// Apply the front matter cascade before the pages are filtered, as
+		// the cascade may set e.g. draft, and before they are added to the
+		// taxonomies, as the cascade may set the taxonomy terms.
+		if err := s.applyCascade(s.rawAllPages); err != nil {
+			return err
+		}
+
 		for _, p := range s.rawAllPages {
 			if !s.isEnabled(p.Kind()) {
 				continue
@@ -746,7 +784,7 @@ func (h *HugoSites) createPageCollections() error {
 			shouldBuild := s.shouldBuild(p)
 			s.buildStats.update(p)
 			if shouldBuild {
-				if p.m.headless {
+				if p.m.noList() && p.m.noRender() {
 					s.headlessPages = append(s.headlessPages, p)
 				} else {
 					s.workAllPages = append(s.workAllPages, p)
@@ -937,6 +975,14 @@ func (h *HugoSites) findPagesByShortcode(shortcode string) page.Pages {
 	return pages
 }
 
+func (h *HugoSites) findPagesWithMarkup() page.Pages {
+	var pages page.Pages
+	for _, s := range h.Sites {
+		pages = append(pages, s.findPagesWithMarkup()...)
+	}
+	return pages
+}
+
 // Used in partial reloading to determine if the change is in a bundle.
 type contentChangeMap struct {
 	mu       sync.RWMutex
@@ -945,6 +991,8 @@ type contentChangeMap struct {
 
 	pathSpec *helpers.PathSpec
 
+	isContentExt isContentExtFunc
+
 	// Hugo supports symlinked content (both directories and files). This
 	// can lead to situations where the same file can be referenced from several
 	// locations in /content -- which is really cool, but also means we have to
@@ -991,7 +1039,7 @@ func (m *contentChangeMap) resolveAndRemove(filename string) (string, string, bu
 		dir += helpers.FilePathSeparator
 	}
 
-	fileTp, isContent := classifyBundledFile(name)
+	fileTp, isContent := m.isContentExt.classifyBundledFile(name)
 
 	// This may be a member of a bundle. Start with branch bundles, the most specific.
 	if fileTp == bundleBranch || (fileTp == bundleNot && !isContent) {
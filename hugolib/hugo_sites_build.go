@@ -235,11 +235,22 @@ func (h *HugoSites) assemble(config *BuildCfg) error {
 		}
 	}
 
+	// Create the pages from the data sources configured in the sections
+	// before the page collections are built from the raw pages.
+	var hasDataPages bool
+	for _, s := range h.Sites {
+		numDataPages, err := s.createDataPages()
+		if err != nil {
+			return err
+		}
+		hasDataPages = hasDataPages || numDataPages > 0
+	}
+
 	if err := h.createPageCollections(); err != nil {
 		return err
 	}
 
-	if config.whatChanged.source {
+	if config.whatChanged.source || hasDataPages {
 		for _, s := range h.Sites {
 			if err := s.assembleTaxonomies(); err != nil {
 				return err
@@ -262,16 +273,69 @@ func (h *HugoSites) assemble(config *BuildCfg) error {
 }
 
 func (h *HugoSites) render(config *BuildCfg) error {
-	siteRenderContext := &siteRenderContext{cfg: config, multihost: h.multihost}
-
 	if !config.PartialReRender {
 		h.renderFormats = output.Formats{}
 		for _, s := range h.Sites {
 			s.initRenderFormats()
 			h.renderFormats = append(h.renderFormats, s.renderFormats...)
 		}
+
+		for _, s := range h.Sites {
+			if err := s.reportTargetPathConflicts(); err != nil {
+				return err
+			}
+		}
+
+		if _, err := h.buildLinkGraph(); err != nil {
+			return err
+		}
 	}
 
+	if err := h.renderSites(config); err != nil {
+		return err
+	}
+
+	if !config.SkipRender && !config.PartialReRender {
+		if err := h.renderChangedBacklinks(config); err != nil {
+			return err
+		}
+	}
+
+	if !config.SkipRender {
+		if err := h.renderCrossSitesArtifacts(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderChangedBacklinks renders the pages again whose backlinks changed by
+// the links resolved with ref or relref when rendering.
+func (h *HugoSites) renderChangedBacklinks(config *BuildCfg) error {
+	// A page may link to its backlinks with ref or relref, so allow a few
+	// passes before giving up.
+	for i := 0; i < 3; i++ {
+		changed, err := h.buildLinkGraph()
+		if err != nil || !changed {
+			return err
+		}
+
+		cfg := *config
+		cfg.PartialReRender = true
+		cfg.backlinksReRender = true
+
+		if err := h.renderSites(&cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HugoSites) renderSites(config *BuildCfg) error {
+	siteRenderContext := &siteRenderContext{cfg: config, multihost: h.multihost}
+
 	i := 0
 	for _, s := range h.Sites {
 		for siteOutIdx, renderFormat := range s.renderFormats {
@@ -316,11 +380,5 @@ func (h *HugoSites) render(config *BuildCfg) error {
 
 	}
 
-	if !config.SkipRender {
-		if err := h.renderCrossSitesArtifacts(); err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
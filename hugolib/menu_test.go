@@ -222,3 +222,111 @@ menu: "main"
 	b.AssertFileContent("public/index.html", "AMP and HTML|/blog/html-amp/|AMP only|/amp/blog/amp/|HTML only|/blog/html/|Home Sweet Home|/|")
 	b.AssertFileContent("public/amp/index.html", "AMP and HTML|/amp/blog/html-amp/|AMP only|/amp/blog/amp/|HTML only|/blog/html/|Home Sweet Home|/amp/|")
 }
+
+func TestMenusFromData(t *testing.T) {
+
+	config := `
+baseURL = "https://example.com"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+weight = 1
+[languages.fr]
+weight = 2
+
+[menus]
+[[menus.main]]
+name = "Config"
+url = "/config/"
+weight = 1
+`
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+
+	b.WithData("menus/main.yaml", `
+- name: "Blog"
+  pageRef: "/blog"
+  weight: 2
+  params:
+    Icon: "pencil"
+- name: "About"
+  url: "/about/"
+  weight: 3
+  identifier: "about"
+- name: "Team"
+  url: "/about/team/"
+  parent: "about"
+`)
+
+	b.WithData("menus/fr/main.yaml", `
+- name: "À propos"
+  url: "/a-propos/"
+`)
+
+	b.WithContent("blog/_index.md", `
+---
+title: "My Blog"
+---
+`)
+
+	b.WithTemplatesAdded("index.html", `{{ range .Site.Menus.main }}{{ .Name }}|{{ .URL }}|{{ with .Page }}Page: {{ .Title }}|{{ end }}{{ with .Params.icon }}Icon: {{ . }}|{{ end }}{{ range .Children }}Child: {{ .Name }}|{{ end }}{{ end }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "Config|/config/|Blog|/blog/|Page: My Blog|Icon: pencil|About|/about/|Child: Team|")
+	b.AssertFileContent("public/fr/index.html", "Config|/config/|À propos|/a-propos/|")
+}
+
+func TestSectionPagesMenuTree(t *testing.T) {
+
+	config := `
+baseURL = "https://example.com"
+sectionPagesMenu = "docs"
+sectionPagesMenuTree = true
+`
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+
+	b.WithContent("docs/_index.md", `
+---
+title: "Docs"
+weight: 1
+---
+`, "docs/install/_index.md", `
+---
+title: "Install"
+weight: 2
+---
+`, "docs/usage/_index.md", `
+---
+title: "Usage"
+weight: 1
+---
+`, "docs/usage/cli/_index.md", `
+---
+title: "CLI"
+---
+`, "docs/usage/cli/p1.md", `
+---
+title: "P1"
+---
+`, "blog/_index.md", `
+---
+title: "Blog"
+weight: 2
+---
+`)
+
+	b.WithTemplatesAdded("partials/menu.html", `
+{{- $p := .page -}}
+{{- range .menu -}}
+{{ .Name }}:{{ .Weight }}:{{ if $p.HasMenuCurrent "docs" . }}Current{{ else }}-{{ end }}[{{ partial "menu.html" (dict "page" $p "menu" .Children) }}]
+{{- end -}}
+`, "_default/single.html", `Menu: {{ partial "menu.html" (dict "page" . "menu" .Site.Menus.docs) }}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/docs/usage/cli/p1/index.html",
+		"Menu: Docs:1:Current[Usage:1:Current[CLI:0:Current[]]Install:2:-[]]Blog:2:-[]")
+}
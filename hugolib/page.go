@@ -34,11 +34,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/gohugoio/hugo/output"
+	"github.com/gohugoio/hugo/related"
 
 	"github.com/gohugoio/hugo/media"
 	"github.com/gohugoio/hugo/source"
 
 	"github.com/gohugoio/hugo/common/collections"
+	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/common/text"
 	"github.com/gohugoio/hugo/resources"
 	"github.com/gohugoio/hugo/resources/page"
@@ -166,6 +168,16 @@ func (p *pageState) RawContent() string {
 	return string(p.source.parsed.Input()[start:])
 }
 
+// RelatedKeywords implements the related.Document interface. An index of type
+// tfidf is built from the words in the rendered content.
+func (p *pageState) RelatedKeywords(cfg related.IndexConfig) ([]related.Keyword, error) {
+	if cfg.Type == related.TypeTFIDF {
+		return related.StringsToKeywords(p.PlainWords()...), nil
+	}
+
+	return p.m.RelatedKeywords(cfg)
+}
+
 func (p *pageState) Resources() resource.Resources {
 	p.resourcesInit.Do(func() {
 
@@ -275,6 +287,7 @@ func (ps *pageState) initCommonProviders(pp pagePaths) error {
 		ps.Positioner = newPagePosition(ps.posNextPrev)
 	}
 
+	ps.TermPositioner = newPagePositionInTerm(ps)
 	ps.OutputFormatsProvider = pp
 	ps.targetPathDescriptor = pp.targetPathDescriptor
 	ps.RefProvider = newPageRef(ps)
@@ -371,6 +384,11 @@ func (p *pageState) renderResources() (err error) {
 				continue
 			}
 
+			if !p.m.buildConfig.PublishResources {
+				// These are published on demand.
+				continue
+			}
+
 			src, ok := r.(resource.Source)
 			if !ok {
 				err = errors.Errorf("Resource %T does not support resource.Source", src)
@@ -542,6 +560,20 @@ Loop:
 				}
 			}
 
+			if meta.frontMatter != nil {
+				// The front matter from a data source, see newPageWithContent.
+				// The front matter in the content takes precedence.
+				maps.ToLower(m)
+				merged := make(map[string]interface{}, len(meta.frontMatter)+len(m))
+				for k, v := range meta.frontMatter {
+					merged[k] = v
+				}
+				for k, v := range m {
+					merged[k] = v
+				}
+				m = merged
+			}
+
 			if err := meta.setMetadata(p, m); err != nil {
 				return err
 			}
@@ -721,7 +753,7 @@ func (p *pageState) shiftToOutputFormat(isRenderingSite bool, idx int) error {
 
 	// Reset any built paginator. This will trigger when re-rendering pages in
 	// server mode.
-	if isRenderingSite && p.pageOutput.paginator != nil && p.pageOutput.paginator.current != nil {
+	if isRenderingSite && p.pageOutput.paginator != nil && p.pageOutput.paginator.isUsed() {
 		p.pageOutput.paginator.reset()
 	}
 
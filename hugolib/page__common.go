@@ -52,6 +52,7 @@ type pageCommon struct {
 	page.RefProvider
 	page.ShortcodeInfoProvider
 	page.SitesProvider
+	page.TermPositioner
 	page.DeprecatedWarningPageMethods
 	page.TranslationsProvider
 	page.TreeProvider
@@ -109,6 +110,14 @@ type pageCommon struct {
 
 	// Set in fast render mode to force render a given page.
 	forceRender bool
+
+	// Set in a rebuild if the pages linking to this page changed.
+	backlinksChanged bool
+
+	// The linkKey of the pages linked to with ref or relref when rendering
+	// this page.
+	refTargetsMu sync.Mutex
+	refTargets   map[string]bool
 }
 
 type pagePages struct {
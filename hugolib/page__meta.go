@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
 	"time"
@@ -101,6 +102,10 @@ type pageMeta struct {
 	// Set if this page is bundled inside another.
 	bundled bool
 
+	// From front matter: whether to list, render and publish the resources
+	// of this page.
+	buildConfig pagemeta.BuildConfig
+
 	// A key that maps to translation(s) of this page. This value is fetched
 	// from the page front matter.
 	translationKey string
@@ -112,6 +117,23 @@ type pageMeta struct {
 	// the Resources above.
 	resourcesMetadata []map[string]interface{}
 
+	// The page's own front matter, kept so the cascade from its ancestors
+	// can be applied again on rebuilds.
+	frontMatter map[string]interface{}
+
+	// From front matter: values to pass down to all descendant pages and
+	// sections, unless they set their own.
+	cascade map[string]interface{}
+
+	// The cascade from the ancestors currently applied to this page.
+	cascaded map[string]interface{}
+
+	// From front matter: the data source to create pages from in this section.
+	dataPages *dataPagesConfig
+
+	// Set if this page is created from a data source and not a content file.
+	fromData bool
+
 	f source.File
 
 	sections []string
@@ -309,16 +331,75 @@ func (pm *pageMeta) setMetadata(p *pageState, frontmatter map[string]interface{}
 		return errors.New("missing frontmatter data")
 	}
 
-	pm.params = make(map[string]interface{})
-
 	// Needed for case insensitive fetching of params values
 	maps.ToLower(frontmatter)
 
+	pm.frontMatter = frontmatter
+	pm.cascaded = nil
+
+	return pm.applyFrontMatter(p, frontmatter)
+}
+
+// applyCascade applies the cascade from the page's ancestors to the page.
+// The values in the page's own front matter take precedence.
+// It reports whether the applied cascade changed.
+func (pm *pageMeta) applyCascade(p *pageState, cascade map[string]interface{}) (bool, error) {
+	if (len(cascade) == 0 && len(pm.cascaded) == 0) || reflect.DeepEqual(cascade, pm.cascaded) {
+		return false, nil
+	}
+
+	frontmatter := make(map[string]interface{}, len(cascade)+len(pm.frontMatter))
+	for k, v := range cascade {
+		frontmatter[k] = v
+	}
+	for k, v := range pm.frontMatter {
+		frontmatter[k] = v
+	}
+
+	pm.cascaded = cascade
+
+	// The previously applied cascade may have set any of the values.
+	pm.resetFrontMatterValues()
+
+	if err := pm.applyFrontMatter(p, frontmatter); err != nil {
+		return false, err
+	}
+
+	return true, pm.applyDefaultValues()
+}
+
+// resetFrontMatterValues resets the values set from front matter.
+func (pm *pageMeta) resetFrontMatterValues() {
+	pm.title, pm.linkTitle = "", ""
+	pm.summary, pm.description = "", ""
+	pm.contentType, pm.layout, pm.markup = "", "", ""
+	pm.translationKey = ""
+	pm.keywords, pm.aliases = nil, nil
+	pm.weight = 0
+	pm.headless, pm.draft = false, false
+	pm.buildConfig = pagemeta.DefaultBuildConfig
+	pm.urlPaths = pagemeta.URLPath{}
+	pm.Dates = resource.Dates{}
+	pm.configuredOutputFormats = nil
+	pm.resourcesMetadata = nil
+	pm.renderingConfig = nil
+	pm.dataPages = nil
+}
+
+func (pm *pageMeta) applyFrontMatter(p *pageState, frontmatter map[string]interface{}) error {
+	pm.params = make(map[string]interface{})
+	pm.cascade = nil
+
 	var mtime time.Time
-	if p.File().FileInfo() != nil {
+	if !p.File().IsZero() && p.File().FileInfo() != nil {
 		mtime = p.File().FileInfo().ModTime()
 	}
 
+	var baseFilename string
+	if !p.File().IsZero() {
+		baseFilename = p.File().ContentBaseName()
+	}
+
 	var gitAuthorDate time.Time
 	if p.gitInfo != nil {
 		gitAuthorDate = p.gitInfo.AuthorDate
@@ -329,7 +410,7 @@ func (pm *pageMeta) setMetadata(p *pageState, frontmatter map[string]interface{}
 		Params:        pm.params,
 		Dates:         &pm.Dates,
 		PageURLs:      &pm.urlPaths,
-		BaseFilename:  p.File().ContentBaseName(),
+		BaseFilename:  baseFilename,
 		ModTime:       mtime,
 		GitAuthorDate: gitAuthorDate,
 	}
@@ -404,7 +485,7 @@ func (pm *pageMeta) setMetadata(p *pageState, frontmatter map[string]interface{}
 		case "headless":
 			// For now, only the leaf bundles ("index.md") can be headless (i.e. produce no output).
 			// We may expand on this in the future, but that gets more complex pretty fast.
-			if p.File().TranslationBaseName() == "index" {
+			if !p.File().IsZero() && p.File().TranslationBaseName() == "index" {
 				pm.headless = cast.ToBool(v)
 			}
 			pm.params[loki] = pm.headless
@@ -422,6 +503,21 @@ func (pm *pageMeta) setMetadata(p *pageState, frontmatter map[string]interface{}
 				}
 
 			}
+		case "_build":
+			pm.buildConfig, err = pagemeta.DecodeBuildConfig(v)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode _build")
+			}
+		case "datapages":
+			pm.dataPages, err = decodeDataPagesConfig(v)
+			if err != nil {
+				return err
+			}
+		case "cascade":
+			pm.cascade = cast.ToStringMap(v)
+			// The cascade is applied to the descendants only.
+			delete(pm.cascade, "cascade")
+			maps.ToLower(pm.cascade)
 		case "draft":
 			draft = new(bool)
 			*draft = cast.ToBool(v)
@@ -526,11 +622,17 @@ func (pm *pageMeta) setMetadata(p *pageState, frontmatter map[string]interface{}
 		}
 	}
 
+	if pm.headless {
+		// A headless bundle is neither listed nor rendered.
+		pm.buildConfig.List = false
+		pm.buildConfig.Render = false
+	}
+
 	if !sitemapSet {
 		pm.sitemap = p.s.siteCfg.sitemap
 	}
 
-	pm.markup = helpers.GuessType(pm.markup)
+	pm.markup = p.s.ContentSpec.ResolveMarkup(pm.markup)
 
 	if draft != nil && published != nil {
 		pm.draft = *draft
@@ -544,7 +646,7 @@ func (pm *pageMeta) setMetadata(p *pageState, frontmatter map[string]interface{}
 
 	if isCJKLanguage != nil {
 		pm.isCJKLanguage = *isCJKLanguage
-	} else if p.s.siteCfg.hasCJKLanguage {
+	} else if p.s.siteCfg.hasCJKLanguage && p.source.parsed != nil {
 		if cjkRe.Match(p.source.parsed.Input()) {
 			pm.isCJKLanguage = true
 		} else {
@@ -561,7 +663,7 @@ func (p *pageMeta) applyDefaultValues() error {
 	if p.markup == "" {
 		if !p.File().IsZero() {
 			// Fall back to file extension
-			p.markup = helpers.GuessType(p.File().Ext())
+			p.markup = p.s.ContentSpec.ResolveMarkup(p.File().Ext())
 		}
 		if p.markup == "" {
 			p.markup = "unknown"
@@ -630,6 +732,22 @@ func (m *pageMeta) outputFormats() output.Formats {
 	return m.s.outputFormats[m.Kind()]
 }
 
+// noList reports whether this page should be left out of the page
+// collections.
+func (p *pageMeta) noList() bool {
+	return !p.buildConfig.List
+}
+
+// noRender reports whether this page should not be rendered.
+func (p *pageMeta) noRender() bool {
+	return !p.buildConfig.Render
+}
+
+// noLink reports whether this page has no permalink of its own.
+func (p *pageMeta) noLink() bool {
+	return p.bundled || p.noRender()
+}
+
 func (p *pageMeta) Slug() string {
 	return p.urlPaths.Slug
 }
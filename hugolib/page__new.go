@@ -30,6 +30,7 @@ import (
 	"github.com/gohugoio/hugo/lazy"
 
 	"github.com/gohugoio/hugo/resources/page"
+	"github.com/gohugoio/hugo/resources/page/pagemeta"
 	"github.com/gohugoio/hugo/resources/resource"
 )
 
@@ -40,6 +41,8 @@ func newPageBase(metaProvider *pageMeta) (*pageState, error) {
 
 	s := metaProvider.s
 
+	metaProvider.buildConfig = pagemeta.DefaultBuildConfig
+
 	ps := &pageState{
 		pageOutput: nopPageOutput,
 		pageCommon: &pageCommon{
@@ -166,7 +169,11 @@ func newPageStandalone(m *pageMeta, f output.Format) (*pageState, error) {
 
 }
 
-func newPageWithContent(f *fileInfo, s *Site, bundled bool, content resource.OpenReadSeekCloser) (*pageState, error) {
+// newPageWithContent creates a new page from the given content file. If
+// frontMatter is set, it is used as the page's front matter, merged with any
+// front matter in the content itself, which takes precedence. This is how
+// the pages created from data sources get theirs.
+func newPageWithContent(f *fileInfo, s *Site, bundled bool, content resource.OpenReadSeekCloser, frontMatter map[string]interface{}) (*pageState, error) {
 	sections := s.sectionsFromFile(f)
 	kind := s.kindFromFileInfoOrSections(f, sections)
 	if kind == page.KindTaxonomy {
@@ -211,6 +218,12 @@ func newPageWithContent(f *fileInfo, s *Site, bundled bool, content resource.Ope
 
 	ps.shortcodeState = newShortcodeHandler(ps, ps.s, nil)
 
+	if frontMatter != nil {
+		if err := metaProvider.setMetadata(ps, frontMatter); err != nil {
+			return nil, ps.wrapError(err)
+		}
+	}
+
 	if err := ps.mapContent(metaProvider); err != nil {
 		return nil, ps.wrapError(err)
 	}
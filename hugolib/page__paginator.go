@@ -17,6 +17,7 @@ import (
 	"sync"
 
 	"github.com/gohugoio/hugo/resources/page"
+	"github.com/pkg/errors"
 )
 
 func newPagePaginator(source *pageState) *pagePaginator {
@@ -34,6 +35,18 @@ type pagePaginator struct {
 type pagePaginatorInit struct {
 	init    sync.Once
 	current *page.Pager
+
+	// The named paginators, in the order they were first used.
+	namedMu sync.Mutex
+	named   []*namedPaginator
+}
+
+// namedPaginator is a paginator created with a name, with its pagers
+// rendered below its own path segment, e.g. /comments/2/.
+type namedPaginator struct {
+	name         string
+	paginatePath string
+	current      *page.Pager
 }
 
 // reset resets the paginator to allow for a rebuild.
@@ -41,18 +54,36 @@ func (p *pagePaginator) reset() {
 	p.pagePaginatorInit = &pagePaginatorInit{}
 }
 
+// isUsed reports whether any paginator has been created for the page.
+func (p *pagePaginator) isUsed() bool {
+	return p.current != nil || p.namedPaginator(0) != nil
+}
+
+// namedPaginator returns the named paginator at index i, or nil if not found.
+func (p *pagePaginator) namedPaginator(i int) *namedPaginator {
+	p.namedMu.Lock()
+	defer p.namedMu.Unlock()
+	if i < len(p.named) {
+		return p.named[i]
+	}
+	return nil
+}
+
 func (p *pagePaginator) Paginate(seq interface{}, options ...interface{}) (*page.Pager, error) {
+	opts, err := page.ResolvePaginatorOptions(p.source.s.Cfg, p.source.Params(), options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Name != "" {
+		return p.paginateNamed(seq, opts)
+	}
+
 	var initErr error
 	p.init.Do(func() {
-		pagerSize, err := page.ResolvePagerSize(p.source.s.Cfg, options...)
-		if err != nil {
-			initErr = err
-			return
-		}
-
 		pd := p.source.targetPathDescriptor
 		pd.Type = p.source.outputFormat()
-		paginator, err := page.Paginate(pd, seq, pagerSize)
+		paginator, err := page.Paginate(pd, seq, opts.PagerSize)
 		if err != nil {
 			initErr = err
 			return
@@ -69,10 +100,43 @@ func (p *pagePaginator) Paginate(seq interface{}, options ...interface{}) (*page
 	return p.current, nil
 }
 
+func (p *pagePaginator) paginateNamed(seq interface{}, opts page.PaginatorOptions) (*page.Pager, error) {
+	p.namedMu.Lock()
+	defer p.namedMu.Unlock()
+
+	for _, n := range p.named {
+		if n.name == opts.Name {
+			return n.current, nil
+		}
+	}
+
+	paginatePath := p.source.s.PathSpec.URLize(opts.Name)
+	if paginatePath == "" || paginatePath == p.source.s.PathSpec.PaginatePath {
+		return nil, errors.Errorf("invalid paginator name %q", opts.Name)
+	}
+	for _, n := range p.named {
+		if n.paginatePath == paginatePath {
+			return nil, errors.Errorf("paginator name %q conflicts with %q", opts.Name, n.name)
+		}
+	}
+
+	pd := p.source.targetPathDescriptor
+	pd.Type = p.source.outputFormat()
+	paginator, err := page.PaginateNamed(pd, paginatePath, seq, opts.PagerSize)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &namedPaginator{name: opts.Name, paginatePath: paginatePath, current: paginator.Pagers()[0]}
+	p.named = append(p.named, n)
+
+	return n.current, nil
+}
+
 func (p *pagePaginator) Paginator(options ...interface{}) (*page.Pager, error) {
 	var initErr error
 	p.init.Do(func() {
-		pagerSize, err := page.ResolvePagerSize(p.source.s.Cfg, options...)
+		pagerSize, err := page.ResolvePagerSize(p.source.s.Cfg, p.source.Params(), options...)
 		if err != nil {
 			initErr = err
 			return
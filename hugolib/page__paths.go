@@ -38,7 +38,7 @@ func newPagePaths(
 		return pagePaths{}, nil
 	}
 
-	if pm.headless {
+	if pm.noRender() {
 		outputFormats = outputFormats[:1]
 	}
 
@@ -52,9 +52,9 @@ func newPagePaths(
 
 		var relPermalink, permalink string
 
-		// If a page is headless or bundled in another, it will not get published
-		// on its own and it will have no links.
-		if !pm.headless && !pm.bundled {
+		// If a page is not rendered or bundled in another, it will not get
+		// published on its own and it will have no links.
+		if !pm.noLink() {
 			relPermalink = paths.RelPermalink(s.PathSpec)
 			permalink = paths.PermalinkForOutputFormat(s.PathSpec, f)
 		}
@@ -127,22 +127,19 @@ func createTargetPathDescriptor(s *Site, p page.Page, pm *pageMeta) (page.Target
 	desc.PrefixFilePath = s.getLanguageTargetPathLang(alwaysInSubDir)
 	desc.PrefixLink = s.getLanguagePermalinkLang(alwaysInSubDir)
 
-	// Expand only page.KindPage and page.KindTaxonomy; don't expand other Kinds of Pages
-	// like page.KindSection or page.KindTaxonomyTerm because they are "shallower" and
-	// the permalink configuration values are likely to be redundant, e.g.
-	// naively expanding /category/:slug/ would give /category/categories/ for
-	// the "categories" page.KindTaxonomyTerm.
-	if p.Kind() == page.KindPage || p.Kind() == page.KindTaxonomy {
-		opath, err := d.ResourceSpec.Permalinks.Expand(p.Section(), p)
-		if err != nil {
-			return desc, err
-		}
-
-		if opath != "" {
-			opath, _ = url.QueryUnescape(opath)
-			desc.ExpandedPermalink = opath
-		}
+	// The permalinks are configured per page kind and section. A pattern set
+	// for a section only applies to its regular pages and taxonomies, as the
+	// other kinds are "shallower" and the same pattern is likely to be
+	// redundant, e.g. naively expanding /category/:slug/ would give
+	// /category/categories/ for the page.KindTaxonomyTerm.
+	opath, err := d.ResourceSpec.Permalinks.Expand(p.Section(), p)
+	if err != nil {
+		return desc, err
+	}
 
+	if opath != "" {
+		opath, _ = url.QueryUnescape(opath)
+		desc.ExpandedPermalink = opath
 	}
 
 	return desc, nil
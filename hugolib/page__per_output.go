@@ -62,8 +62,9 @@ func newPageContentOutput(p *pageState) func(f output.Format) (*pageContentOutpu
 
 	return func(f output.Format) (*pageContentOutput, error) {
 		cp := &pageContentOutput{
-			p: p,
-			f: f,
+			p:   p,
+			f:   f,
+			toc: &helpers.TableOfContents{},
 		}
 
 		initContent := func() error {
@@ -80,16 +81,24 @@ func newPageContentOutput(p *pageState) func(f output.Format) (*pageContentOutpu
 				cp.enableReuse()
 			}
 
+			// Reserve the heading IDs in the shortcodes first, so the IDs in
+			// the content are unique across the page.
+			cp.headingIDs = p.s.ContentSpec.NewHeadingIDs()
+			p.shortcodeState.uniqueHeadingIDs(cp.contentPlaceholders, cp.headingIDs)
+
 			cp.workContent = p.contentToRender(cp.contentPlaceholders)
 
 			isHTML := cp.p.m.markup == "html"
 
 			if p.renderable {
 				if !isHTML {
-					cp.workContent = cp.renderContent(p, cp.workContent)
-					tmpContent, tmpTableOfContents := helpers.ExtractTOC(cp.workContent)
-					cp.tableOfContents = helpers.BytesToHTML(tmpTableOfContents)
-					cp.workContent = tmpContent
+					cp.workContent, cp.toc = cp.renderContent(p, cp.workContent)
+					if p.cmap.hasNonMarkdownShortcode {
+						if err := replaceTOCShortcodeTokens(cp.toc.Headings, cp.contentPlaceholders); err != nil {
+							return err
+						}
+					}
+					cp.tableOfContents = cp.toc.ToHTML()
 				}
 
 				if cp.placeholdersEnabled {
@@ -133,7 +142,8 @@ func newPageContentOutput(p *pageState) func(f output.Format) (*pageContentOutpu
 						Content: []byte(cp.p.m.summary), RenderTOC: false, PageFmt: cp.p.m.markup,
 						Cfg:        p.Language(),
 						DocumentID: p.File().UniqueID(), DocumentName: p.File().Path(),
-						Config: cp.p.getRenderingConfig()})
+						Config: cp.p.getRenderingConfig(),
+						Page:   p, RenderHooks: p.s.renderHooks()})
 					html = cp.p.s.ContentSpec.TrimShortHTML(html)
 					cp.summary = helpers.BytesToHTML(html)
 				}
@@ -212,6 +222,10 @@ type pageContentOutput struct {
 	content         template.HTML
 	summary         template.HTML
 	tableOfContents template.HTML
+	toc             *helpers.TableOfContents
+
+	// The heading IDs in use in the page's content and shortcodes.
+	headingIDs *helpers.HeadingIDs
 
 	truncated bool
 
@@ -265,6 +279,11 @@ func (p *pageContentOutput) TableOfContents() template.HTML {
 	return p.tableOfContents
 }
 
+func (p *pageContentOutput) TOC() *helpers.TableOfContents {
+	p.p.s.initInit(p.initMain, p.p)
+	return p.toc
+}
+
 func (p *pageContentOutput) Truncated() bool {
 	if p.p.truncated {
 		return true
@@ -299,12 +318,35 @@ func (p *pageContentOutput) setAutoSummary() error {
 
 }
 
-func (cp *pageContentOutput) renderContent(p page.Page, content []byte) []byte {
-	return cp.p.s.ContentSpec.RenderBytes(&helpers.RenderingContext{
+func (cp *pageContentOutput) renderContent(p page.Page, content []byte) ([]byte, *helpers.TableOfContents) {
+	ctx := &helpers.RenderingContext{
 		Content: content, RenderTOC: true, PageFmt: cp.p.m.markup,
 		Cfg:        p.Language(),
 		DocumentID: p.File().UniqueID(), DocumentName: p.File().Path(),
-		Config: cp.p.getRenderingConfig()})
+		Config: cp.p.getRenderingConfig(),
+		Page:   p, RenderHooks: cp.p.s.renderHooks(),
+		HeadingIDs: cp.headingIDs}
+
+	content = cp.p.s.ContentSpec.RenderBytes(ctx)
+
+	return content, ctx.TOC
+}
+
+// replaceTOCShortcodeTokens replaces the shortcode tokens in the heading
+// texts, e.g. from a shortcode used in a heading.
+func replaceTOCShortcodeTokens(headings helpers.TOCHeadings, replacements map[string]string) error {
+	for _, h := range headings {
+		text, err := replaceShortcodeTokens([]byte(h.Text), replacements)
+		if err != nil {
+			return err
+		}
+		h.Text = template.HTML(text)
+
+		if err := replaceTOCShortcodeTokens(h.Children, replacements); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (p *pageContentOutput) setWordCounts(isCJKLanguage bool) {
@@ -16,6 +16,7 @@ package hugolib
 import (
 	"github.com/gohugoio/hugo/lazy"
 	"github.com/gohugoio/hugo/resources/page"
+	"github.com/pkg/errors"
 )
 
 func newPagePosition(n *nextPrev) pagePosition {
@@ -27,6 +28,10 @@ func newPagePositionInSection(n *nextPrev) pagePositionInSection {
 
 }
 
+func newPagePositionInTerm(p *pageState) pagePositionInTerm {
+	return pagePositionInTerm{p: p}
+}
+
 type nextPrev struct {
 	init     *lazy.Init
 	prevPage page.Page
@@ -74,3 +79,29 @@ func (p pagePositionInSection) NextInSection() page.Page {
 func (p pagePositionInSection) PrevInSection() page.Page {
 	return p.prev()
 }
+
+type pagePositionInTerm struct {
+	p *pageState
+}
+
+func (pt pagePositionInTerm) PositionIn(taxonomy, term string) (*page.TermPosition, error) {
+	s := pt.p.s
+
+	v, err := s.init.termPositions.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	terms, found := v.(map[string]map[string]map[page.Page]int)[taxonomy]
+	if !found {
+		return nil, errors.Errorf("taxonomy %q not found", taxonomy)
+	}
+
+	key := s.getTaxonomyKey(term)
+	i, found := terms[key][pt.p]
+	if !found {
+		return nil, nil
+	}
+
+	return page.NewTermPosition(s.Taxonomies[taxonomy][key], i), nil
+}
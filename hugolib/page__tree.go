@@ -112,6 +112,59 @@ func (pt pageTree) Parent() page.Page {
 	return pt.p.parent
 }
 
+func (pt pageTree) Ancestors() page.Pages {
+	var ancestors page.Pages
+	for parent := treeParent(pt.p); parent != nil; parent = treeParent(parent) {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+func (pt pageTree) Breadcrumbs() page.Pages {
+	ancestors := pt.Ancestors()
+	breadcrumbs := make(page.Pages, 0, len(ancestors)+1)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		breadcrumbs = append(breadcrumbs, ancestors[i])
+	}
+	return append(breadcrumbs, pt.p)
+}
+
+// treeParent returns the page above p in the site tree. This is the same
+// as Parent, but taxonomy and taxonomy terms pages, which have no section,
+// are placed below the taxonomy's terms page and the home page.
+func treeParent(p *pageState) *pageState {
+	if p.parent != nil {
+		return p.parent
+	}
+
+	switch p.Kind() {
+	case page.KindHome:
+		return nil
+	case page.KindTaxonomy:
+		if info := p.getTaxonomyNodeInfo(); info != nil && info.parent != nil && info.parent.owner != nil {
+			if pp, ok := info.parent.owner.Page.(*pageState); ok && pp != nil {
+				return pp
+			}
+		}
+	}
+
+	if p.s.home == p {
+		return nil
+	}
+
+	return p.s.home
+}
+
+func (pt pageTree) Children() page.Pages {
+	switch pt.p.Kind() {
+	case page.KindTaxonomy, page.KindTaxonomyTerm:
+		if info := pt.p.getTaxonomyNodeInfo(); info != nil {
+			return info.childPages()
+		}
+	}
+	return nil
+}
+
 func (pt pageTree) Sections() page.Pages {
 	return pt.p.subSections
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/deps"
 )
 
@@ -148,3 +149,59 @@ Some content.
 	b.AssertFileContent("public/myblog/p3/index.html", "Single: A page|Hello|en|RelPermalink: /myblog/p3/|Permalink: https://example.com/myblog/p3/|")
 
 }
+
+func TestPermalinksPerKind(t *testing.T) {
+
+	config := `
+baseURL = "https://example.com"
+
+[taxonomies]
+tag = "tags"
+
+[permalinks]
+posts = "/:params.series/:slugorcontentbasename/"
+
+[permalinks.section]
+posts = "/articles/"
+
+[permalinks.taxonomy]
+tags = "/topics/:slug/"
+
+[permalinks.taxonomyTerm]
+tags = "/topics/"
+`
+
+	logger := loggers.NewErrorLogger()
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config).WithLogger(logger)
+
+	b.WithContent("posts/_index.md", `---
+title: "Posts"
+---
+`)
+	b.WithContent("posts/my-post.md", `---
+title: "My Post"
+series: "Hugo Tips"
+tags: ["Go Lang"]
+---
+`)
+	b.WithContent("posts/my-bundle/index.md", `---
+title: "My Bundle"
+series: "Hugo Tips"
+slug: "custom"
+---
+`)
+	b.WithContent("posts/conflict.md", `---
+title: "Conflict"
+url: "/hugo-tips/my-post/"
+---
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/articles/index.html", "List Page 1|Posts|")
+	b.AssertFileContent("public/hugo-tips/custom/index.html", "Single: My Bundle|")
+	b.AssertFileContent("public/topics/go-lang/index.html", "https://example.com/topics/go-lang/")
+	b.AssertFileContent("public/topics/index.html", "https://example.com/topics/")
+
+	require.Equal(t, uint64(1), logger.ErrorCounter.Count())
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/gohugoio/hugo/common/loggers"
 
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -453,6 +454,93 @@ func TestPageWithDelimiterForMarkdownThatCrossesBorder(t *testing.T) {
 	}
 }
 
+func TestPageWithCommonmarkMarkup(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile().WithTemplatesAdded("_default/single.html", `{{ .Content }}|TOC: {{ .TableOfContents }}`)
+	b.WithContent("commonmark.md", `---
+title: CommonMark
+markup: commonmark
+---
+
+## Heading
+
+- a
+  - b
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/commonmark/index.html",
+		"<h2 id=\"heading\">Heading</h2>",
+		"<li>a\n<ul>\n<li>b</li>\n</ul>\n</li>",
+		`TOC: <nav id="TableOfContents">`,
+		`<li><a href="#heading">Heading</a></li>`)
+}
+
+func TestDefaultMarkdownHandler(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+defaultMarkdownHandler = "commonmark"
+`)
+	b.WithTemplatesAdded("_default/single.html", `{{ .Content }}`)
+	b.WithContent("page.md", `---
+title: Page
+---
+- a
+  - b
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html", "<li>a\n<ul>\n<li>b</li>\n</ul>\n</li>")
+}
+
+func TestPageWithExternalConverter(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("skip cat on Windows")
+	}
+
+	b := newTestSitesBuilder(t)
+	b.WithConfigFile("toml", `
+baseURL = "http://example.com/"
+[markup.pandoc]
+command = "cat"
+[markup.raw]
+command = "cat"
+extensions = ["htm"]
+[markup.textile]
+command = "cat"
+extensions = ["txtl"]
+`)
+	b.WithTemplatesAdded("_default/single.html", `{{ .Content }}`)
+	b.WithContent("page.pdc", `---
+title: Page
+---
+<p>Hello **pandoc**.</p>
+`, "htmpage.htm", `---
+title: HTM Page
+---
+<p>Hello **raw**.</p>
+`, "txtlpage.txtl", `---
+title: Textile Page
+---
+<p>Hello **textile**.</p>
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/page/index.html", "<p>Hello **pandoc**.</p>")
+	b.AssertFileContent("public/htmpage/index.html", "<p>Hello **raw**.</p>")
+	b.AssertFileContent("public/txtlpage/index.html", "<p>Hello **textile**.</p>")
+}
+
 func TestPageDatesAllKinds(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -709,6 +797,59 @@ func TestTableOfContents(t *testing.T) {
 	checkPageTOC(t, p, "<nav id=\"TableOfContents\">\n<ul>\n<li>\n<ul>\n<li><a href=\"#aa\">AA</a>\n<ul>\n<li><a href=\"#aaa\">AAA</a></li>\n<li><a href=\"#bbb\">BBB</a></li>\n</ul></li>\n</ul></li>\n</ul>\n</nav>")
 }
 
+func TestTableOfContentsLevels(t *testing.T) {
+	t.Parallel()
+
+	for _, handler := range []string{"blackfriday", "commonmark"} {
+		handler := handler
+		t.Run(handler, func(t *testing.T) {
+			t.Parallel()
+
+			b := newTestSitesBuilder(t)
+			b.WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "http://example.com/"
+defaultMarkdownHandler = %q
+[tableOfContents]
+startLevel = 2
+endLevel = 3
+`, handler))
+			b.WithTemplatesAdded("_default/single.html", `{{ .TableOfContents }}
+{{ range .TOC.Headings }}Heading: {{ .Level }}|{{ .ID }}|{{ .Text }}|{{ len .Children }}
+{{ end }}
+<script>var toc = {{ .TOC.JSON }};</script>`)
+			b.WithContent("page.md", `---
+title: Page
+---
+# Title
+
+## First
+
+### Sub *one*
+
+#### Deep
+
+## Second
+`)
+
+			b.Build(BuildCfg{})
+
+			b.AssertFileContent("public/page/index.html", `<nav id="TableOfContents">
+<ul>
+<li><a href="#first">First</a>
+<ul>
+<li><a href="#sub-one">Sub <em>one</em></a></li>
+</ul></li>
+<li><a href="#second">Second</a></li>
+</ul>
+</nav>`,
+				"Heading: 2|first|First|1",
+				"Heading: 2|second|Second|0",
+				`<script>var toc = [{"id":"first","text":"First","level":2,"children":[{"id":"sub-one","text":"Sub \u003cem\u003eone\u003c/em\u003e","level":3}]},{"id":"second","text":"Second","level":2}];</script>`,
+			)
+		})
+	}
+}
+
 func TestPageWithMoreTag(t *testing.T) {
 	t.Parallel()
 	assertFunc := func(t *testing.T, ext string, pages page.Pages) {
@@ -1537,3 +1678,35 @@ title: Scratch Me!
 	b.AssertFileContent("public/index.html", "B: bv")
 	b.AssertFileContent("public/scratchme/index.html", "C: cv")
 }
+
+// Sorting by a key that renders content must not hold the page cache lock,
+// as the content may sort pages itself.
+func TestPagesSortByWordCountWithSortInShortcode(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t)
+	b.WithSimpleConfigFile().WithTemplatesAdded("index.html", `
+{{ range (.Site.RegularPages.SortBy "WordCount" "desc") }}{{ .Title }}|{{ end }}
+`,
+		"shortcodes/sorted.html", `{{ range .Page.Site.RegularPages.ByWeight }}{{ .Title }} {{ end }}`,
+	)
+
+	b.WithContentAdded("p1.md", `
+---
+title: P1
+weight: 1
+---
+
+{{< sorted >}} one two three four five
+`, "p2.md", `
+---
+title: P2
+weight: 2
+---
+
+{{< sorted >}}
+`)
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/index.html", "P1|P2|")
+}
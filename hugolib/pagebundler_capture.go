@@ -53,6 +53,8 @@ type capturer struct {
 	fs         afero.Fs
 	logger     *loggers.Logger
 
+	isContentExt isContentExtFunc
+
 	// Filenames limits the content to process to a list of filenames/directories.
 	// This is used for partial building in server mode.
 	filenames []string
@@ -67,6 +69,7 @@ type capturer struct {
 func newCapturer(
 	logger *loggers.Logger,
 	sourceSpec *source.SourceSpec,
+	isContentExt isContentExtFunc,
 	handler captureResultHandler,
 	contentChanges *contentChangeMap,
 	filenames ...string) *capturer {
@@ -77,7 +80,7 @@ func newCapturer(
 	isBundleHeader := func(filename string) bool {
 		base := filepath.Base(filename)
 		name := helpers.Filename(base)
-		return IsContentFile(base) && (name == "index" || name == "_index")
+		return isContentExt.isContentFile(base) && (name == "index" || name == "_index")
 	}
 
 	// Make sure that any bundle header files are processed before the others. This makes
@@ -101,6 +104,7 @@ func newCapturer(
 		sem:            make(chan bool, numWorkers),
 		handler:        handler,
 		sourceSpec:     sourceSpec,
+		isContentExt:   isContentExt,
 		fs:             sourceSpec.SourceFs,
 		logger:         logger,
 		contentChanges: contentChanges,
@@ -241,7 +245,7 @@ func (c *capturer) handleBranchDir(dirname string) error {
 
 	for _, fi := range files {
 		if !fi.IsDir() {
-			tp, _ := classifyBundledFile(fi.RealName())
+			tp, _ := c.isContentExt.classifyBundledFile(fi.RealName())
 			if dirType == bundleNot {
 				dirType = tp
 			}
@@ -266,7 +270,7 @@ func (c *capturer) handleBranchDir(dirname string) error {
 			continue
 		}
 
-		tp, isContent := classifyBundledFile(fi.RealName())
+		tp, isContent := c.isContentExt.classifyBundledFile(fi.RealName())
 
 		f, active := c.newFileInfo(fi, tp)
 
@@ -337,7 +341,7 @@ func (c *capturer) handleDir(dirname string) error {
 
 	for i, fi := range files {
 		if !fi.IsDir() {
-			tp, isContent := classifyBundledFile(fi.RealName())
+			tp, isContent := c.isContentExt.classifyBundledFile(fi.RealName())
 
 			fileBundleTypes[i] = tp
 			if !isBranch {
@@ -397,7 +401,7 @@ func (c *capturer) handleDir(dirname string) error {
 				if err := c.handleNestedDir(fi.Path()); err != nil {
 					return err
 				}
-			} else if bundleType == bundleNot || (!fi.isOwner() && fi.isContentFile()) {
+			} else if bundleType == bundleNot || (!fi.isOwner() && c.isContentExt(fi.Ext())) {
 				// Not in a bundle.
 				c.copyOrHandleSingle(fi)
 			} else {
@@ -451,7 +455,7 @@ func (c *capturer) handleNonBundle(
 }
 
 func (c *capturer) copyOrHandleSingle(fi *fileInfo) {
-	if fi.isContentFile() {
+	if c.isContentExt(fi.Ext()) {
 		c.handler.handleSingles(fi)
 	} else {
 		// These do not currently need any further processing.
@@ -499,7 +503,7 @@ func (c *capturer) createBundleDirs(fileInfos []*fileInfo, bundleType bundleDirT
 			continue
 		}
 
-		if fi.isContentFile() {
+		if c.isContentExt(fi.Ext()) {
 			if bundleType != bundleBranch {
 				dirs.addBundleContentFile(fi)
 			}
@@ -92,7 +92,7 @@ func TestPageBundlerCaptureSymlinks(t *testing.T) {
 
 	fileStore := &storeFilenames{}
 	logger := loggers.NewErrorLogger()
-	c := newCapturer(logger, sourceSpec, fileStore, nil)
+	c := newCapturer(logger, sourceSpec, isDefaultContentExt, fileStore, nil)
 
 	assert.NoError(c.capture())
 
@@ -135,7 +135,7 @@ func TestPageBundlerCaptureBasic(t *testing.T) {
 
 	fileStore := &storeFilenames{}
 
-	c := newCapturer(loggers.NewErrorLogger(), sourceSpec, fileStore, nil)
+	c := newCapturer(loggers.NewErrorLogger(), sourceSpec, isDefaultContentExt, fileStore, nil)
 
 	assert.NoError(c.capture())
 
@@ -179,7 +179,7 @@ func TestPageBundlerCaptureMultilingual(t *testing.T) {
 
 	sourceSpec := source.NewSourceSpec(ps, ps.BaseFs.Content.Fs)
 	fileStore := &storeFilenames{}
-	c := newCapturer(loggers.NewErrorLogger(), sourceSpec, fileStore, nil)
+	c := newCapturer(loggers.NewErrorLogger(), sourceSpec, isDefaultContentExt, fileStore, nil)
 
 	assert.NoError(c.capture())
 
@@ -259,7 +259,7 @@ func BenchmarkPageBundlerCapture(b *testing.B) {
 			writeSource(b, fs, filepath.Join(base, "contentonly", fmt.Sprintf("c%d.md", i)), "content")
 		}
 
-		capturers[i] = newCapturer(loggers.NewErrorLogger(), sourceSpec, new(noOpFileStore), nil, base)
+		capturers[i] = newCapturer(loggers.NewErrorLogger(), sourceSpec, isDefaultContentExt, new(noOpFileStore), nil, base)
 	}
 
 	b.ResetTimer()
@@ -162,10 +162,6 @@ func (c *handlerContext) supports(exts ...string) bool {
 	return false
 }
 
-func (c *handlerContext) isContentFile() bool {
-	return contentFileExtensionsSet[c.ext()]
-}
-
 type (
 	handlerResult struct {
 		err     error
@@ -182,7 +178,7 @@ var (
 
 func (c *contentHandlers) parsePage(h contentHandler) contentHandler {
 	return func(ctx *handlerContext) handlerResult {
-		if !ctx.isContentFile() {
+		if !c.s.isContentExt(ctx.ext()) {
 			return notHandled
 		}
 
@@ -197,7 +193,7 @@ func (c *contentHandlers) parsePage(h contentHandler) contentHandler {
 			return f, nil
 		}
 
-		ps, err := newPageWithContent(fi, c.s, ctx.parentPage != nil, content)
+		ps, err := newPageWithContent(fi, c.s, ctx.parentPage != nil, content, nil)
 		if err != nil {
 			return handlerResult{err: err}
 		}
@@ -279,6 +275,7 @@ func (c *contentHandlers) createResource() contentHandler {
 				SourceFile:        ctx.source,
 				RelTargetFilename: ctx.target,
 				TargetBasePaths:   targetBasePaths,
+				LazyPublish:       !ctx.parentPage.m.buildConfig.PublishResources,
 			})
 
 		return handlerResult{err: err, handled: true, result: resource}
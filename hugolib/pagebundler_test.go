@@ -942,3 +942,74 @@ date: 2017-01-15
 	b.AssertFileContent("public/mybundle/data.json", "My changed data")
 
 }
+
+func TestPageBundlerBuildOptions(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithTemplatesAdded(
+		"index.html", `{{ range .Site.RegularPages }}|{{ .Title }}:{{ .RelPermalink }}{{ end }}
+GETPAGE: {{ with .Site.GetPage "no-list" }}{{ .Title }}:{{ .RelPermalink }}{{ end }}|{{ with .Site.GetPage "data" }}{{ .Title }}:{{ .RelPermalink }}{{ end }}`,
+		"_default/single.html", `SINGLE: {{ .Title }}|{{ with .Resources.GetMatch "used.txt" }}{{ .RelPermalink }}{{ end }}`,
+	)
+
+	b.WithContent(
+		"regular.md", `---
+title: Regular
+---
+`,
+		"no-list.md", `---
+title: NoList
+_build:
+  list: false
+---
+`,
+		"no-render/index.md", `---
+title: NoRender
+_build:
+  render: false
+---
+`,
+		"no-render/data.txt", "no-render data",
+		"no-publish/index.md", `---
+title: NoPublish
+_build:
+  publishResources: false
+---
+`,
+		"no-publish/used.txt", "used",
+		"no-publish/unused.txt", "unused",
+		"data/index.md", `---
+title: Data
+_build:
+  list: false
+  render: false
+---
+`,
+	)
+
+	b.Build(BuildCfg{})
+
+	s := b.H.Sites[0]
+	assert.Len(s.RegularPages(), 3)
+	assert.Len(s.headlessPages, 1)
+
+	b.AssertFileContent("public/index.html",
+		"|NoPublish:/no-publish/|NoRender:|Regular:/regular/\n",
+		"GETPAGE: NoList:/no-list/|Data:")
+
+	b.AssertFileContent("public/no-list/index.html", "SINGLE: NoList")
+	assert.False(b.CheckExists("public/no-render/index.html"))
+	assert.False(b.CheckExists("public/data/index.html"))
+
+	// The resources of a page that is not rendered are published.
+	b.AssertFileContent("public/no-render/data.txt", "no-render data")
+
+	// Only the resources used are published.
+	b.AssertFileContent("public/no-publish/index.html", "SINGLE: NoPublish|/no-publish/used.txt")
+	b.AssertFileContent("public/no-publish/used.txt", "used")
+	assert.False(b.CheckExists("public/no-publish/unused.txt"))
+}
@@ -39,7 +39,8 @@ type PageCollections struct {
 	// rawAllPages plus additional pages created during the build process.
 	workAllPages pageStatePages
 
-	// Includes headless bundles, i.e. bundles that produce no output for its content page.
+	// Includes headless bundles, i.e. bundles that produce no output for its content page,
+	// and the other pages that are neither listed nor rendered.
 	headlessPages pageStatePages
 
 	// Lazy initialized page collections
@@ -120,15 +121,18 @@ func newPageCollectionsFromPages(pages pageStatePages) *PageCollections {
 	c := &PageCollections{rawAllPages: pages}
 
 	c.pages = newLazyPagesFactory(func() page.Pages {
-		pages := make(page.Pages, len(c.workAllPages))
-		for i, p := range c.workAllPages {
-			pages[i] = p
+		pages := make(page.Pages, 0, len(c.workAllPages))
+		for _, p := range c.workAllPages {
+			if p.m.noList() {
+				continue
+			}
+			pages = append(pages, p)
 		}
 		return pages
 	})
 
 	c.regularPages = newLazyPagesFactory(func() page.Pages {
-		return c.findPagesByKindInWorkPages(page.KindPage, c.workAllPages)
+		return c.findPagesByKindIn(page.KindPage, c.pages.get())
 	})
 
 	c.pageIndex = cache.NewLazy(func() (map[string]interface{}, error) {
@@ -331,16 +335,6 @@ func (c *PageCollections) findWorkPagesByKind(kind string) pageStatePages {
 	return pages
 }
 
-func (*PageCollections) findPagesByKindInWorkPages(kind string, inPages pageStatePages) page.Pages {
-	var pages page.Pages
-	for _, p := range inPages {
-		if p.Kind() == kind {
-			pages = append(pages, p)
-		}
-	}
-	return pages
-}
-
 func (c *PageCollections) findFirstWorkPageByKindIn(kind string) *pageState {
 	for _, p := range c.workAllPages {
 		if p.Kind() == kind {
@@ -372,7 +366,20 @@ func (c *PageCollections) removePage(page *pageState) {
 func (c *PageCollections) findPagesByShortcode(shortcode string) page.Pages {
 	var pages page.Pages
 	for _, p := range c.rawAllPages {
-		if p.HasShortcode(shortcode) {
+		// The pages created from data are recreated on every build.
+		if p.HasShortcode(shortcode) && !p.m.fromData {
+			pages = append(pages, p)
+		}
+	}
+	return pages
+}
+
+// findPagesWithMarkup returns the pages with content rendered from markup,
+// e.g. Markdown, which are the pages affected by a change in the render hooks.
+func (c *PageCollections) findPagesWithMarkup() page.Pages {
+	var pages page.Pages
+	for _, p := range c.rawAllPages {
+		if p.renderable && !p.File().IsZero() && !p.m.fromData && p.m.markup != "html" {
 			pages = append(pages, p)
 		}
 	}
@@ -0,0 +1,330 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gohugoio/hugo/common/hugio"
+	"github.com/gohugoio/hugo/common/maps"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/parser/metadecoders"
+	"github.com/gohugoio/hugo/resources/page"
+	"github.com/gohugoio/hugo/source"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cast"
+)
+
+// dataPagesConfig configures the pages to create from a data source in a
+// section, set with the dataPages key in the section's front matter.
+type dataPagesConfig struct {
+	// The key of a data file in /data, e.g. "products" for data/products.json
+	// or "catalog/products" for data/catalog/products.yaml.
+	Source string
+
+	// The path to a JSON, YAML, TOML or CSV file in /assets, as used with
+	// resources.Get, e.g. "catalog/products.csv".
+	Resource string
+
+	// The markup of the content in the entries. Defaults to markdown.
+	Markup string
+}
+
+func decodeDataPagesConfig(v interface{}) (*dataPagesConfig, error) {
+	var c dataPagesConfig
+	if err := mapstructure.WeakDecode(v, &c); err != nil {
+		return nil, errors.Wrap(err, "failed to decode dataPages")
+	}
+
+	if (c.Source == "") == (c.Resource == "") {
+		return nil, errors.New("dataPages must have either a source or a resource")
+	}
+
+	return &c, nil
+}
+
+// The entry keys with a special meaning. Any other key is used as front matter.
+const (
+	dataPageKeyPath    = "path"
+	dataPageKeyContent = "content"
+	dataPageKeyParams  = "params"
+)
+
+// createDataPages creates the pages from the data sources configured in the
+// sections' front matter, replacing the ones from any previous build.
+// It returns the number of pages created.
+func (s *Site) createDataPages() (int, error) {
+	var (
+		pages   pageStatePages
+		owners  pageStatePages
+		created int
+	)
+
+	for _, p := range s.rawAllPages {
+		if p.m.fromData {
+			continue
+		}
+		pages = append(pages, p)
+
+		if p.m.dataPages == nil || p.File().IsZero() {
+			continue
+		}
+		if p.Kind() == page.KindSection || p.Kind() == page.KindHome {
+			owners = append(owners, p)
+		}
+	}
+
+	s.rawAllPages = pages
+
+	for _, owner := range owners {
+		entries, err := s.readDataPagesSource(owner.m.dataPages)
+		if err != nil {
+			return 0, owner.errorf(err, "failed to read the dataPages source")
+		}
+
+		for _, entry := range entries {
+			p, err := s.newDataPage(owner, entry)
+			if err != nil {
+				return 0, owner.errorf(err, "failed to create page from data")
+			}
+			s.addPage(p)
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+// readDataPagesSource reads the entries to create pages from. A data source
+// with a map at the top level creates one page per key, sorted by key.
+func (s *Site) readDataPagesSource(cfg *dataPagesConfig) ([]dataPageEntry, error) {
+	var (
+		data interface{}
+		err  error
+	)
+
+	if cfg.Source != "" {
+		data, err = s.getDataPagesSourceData(cfg.Source)
+	} else {
+		data, err = s.getDataPagesResourceData(cfg.Resource)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []dataPageEntry
+
+	switch v := data.(type) {
+	case []interface{}:
+		for _, vv := range v {
+			m, err := cast.ToStringMapE(vv)
+			if err != nil {
+				return nil, errors.Wrap(err, "the data entries must be maps")
+			}
+			entries = append(entries, dataPageEntry{values: m})
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			m, err := cast.ToStringMapE(v[k])
+			if err != nil {
+				return nil, errors.Wrap(err, "the data entries must be maps")
+			}
+			entries = append(entries, dataPageEntry{key: k, values: m})
+		}
+	case [][]string:
+		// CSV, with the column names in the first row.
+		if len(v) == 0 {
+			return nil, nil
+		}
+		header := v[0]
+		for _, row := range v[1:] {
+			m := make(map[string]interface{})
+			for i, col := range row {
+				if i < len(header) {
+					m[header[i]] = col
+				}
+			}
+			entries = append(entries, dataPageEntry{values: m})
+		}
+	default:
+		return nil, errors.Errorf("unsupported data type %T", data)
+	}
+
+	for i := range entries {
+		entries[i].markup = cfg.Markup
+	}
+
+	return entries, nil
+}
+
+func (s *Site) getDataPagesSourceData(key string) (interface{}, error) {
+	var data interface{} = s.h.Data()
+
+	for _, part := range strings.FieldsFunc(key, func(r rune) bool { return r == '/' || r == '.' }) {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("data source %q not found", key)
+		}
+		if data, ok = m[part]; !ok {
+			return nil, errors.Errorf("data source %q not found", key)
+		}
+	}
+
+	return data, nil
+}
+
+func (s *Site) getDataPagesResourceData(filename string) (interface{}, error) {
+	filename = filepath.FromSlash(strings.TrimPrefix(filename, "/"))
+
+	format := metadecoders.FormatFromString(filename)
+	if format == "" {
+		return nil, errors.Errorf("unsupported format for resource %q", filename)
+	}
+
+	b, err := afero.ReadFile(s.BaseFs.Assets.Fs, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadecoders.Default.Unmarshal(b, format)
+}
+
+// dataPageEntry is an entry in a data source that becomes a page.
+type dataPageEntry struct {
+	// The key of the entry if the data source is a map.
+	key string
+
+	values map[string]interface{}
+
+	markup string
+}
+
+// frontMatter returns the entry's front matter, content and its path relative
+// to the owning section.
+func (e dataPageEntry) frontMatter(ps *helpers.PathSpec) (map[string]interface{}, string, string, error) {
+	fm := make(map[string]interface{})
+	for k, v := range e.values {
+		fm[k] = v
+	}
+	maps.ToLower(fm)
+
+	content := cast.ToString(fm[dataPageKeyContent])
+	delete(fm, dataPageKeyContent)
+
+	if params, found := fm[dataPageKeyParams]; found {
+		delete(fm, dataPageKeyParams)
+		m, err := cast.ToStringMapE(params)
+		if err != nil {
+			return nil, "", "", errors.Wrap(err, "params must be a map")
+		}
+		for k, v := range m {
+			if _, found := fm[k]; !found {
+				fm[k] = v
+			}
+		}
+	}
+
+	name := cast.ToString(fm[dataPageKeyPath])
+	delete(fm, dataPageKeyPath)
+	if name == "" {
+		name = e.key
+	}
+	if name == "" {
+		name = ps.URLize(cast.ToString(fm["title"]))
+	}
+
+	name = strings.Trim(path.Clean("/"+filepath.ToSlash(name)), "/")
+	if name == "" {
+		return nil, "", "", errors.New("the entry must have a path or a title")
+	}
+
+	if _, found := fm["markup"]; !found && e.markup != "" {
+		fm["markup"] = e.markup
+	}
+
+	return fm, content, name, nil
+}
+
+// newDataPage creates a page in the section owner from the given entry.
+func (s *Site) newDataPage(owner *pageState, entry dataPageEntry) (*pageState, error) {
+	fm, content, name, err := entry.frontMatter(s.PathSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerFile, ok := owner.File().(*fileInfo)
+	if !ok {
+		return nil, errors.Errorf("unsupported file type %T", owner.File())
+	}
+
+	relFilename := filepath.Join(ownerFile.Dir(), filepath.FromSlash(name)+".md")
+
+	fi := &dataPageFileInfo{
+		filename:            filepath.Join(ownerFile.BaseDir(), relFilename),
+		path:                relFilename,
+		baseDir:             ownerFile.BaseDir(),
+		lang:                ownerFile.Lang(),
+		translationBaseName: path.Base(name),
+	}
+
+	sp := source.NewSourceSpec(s.PathSpec, s.BaseFs.Content.Fs)
+	f := newFileInfo(sp, fi.baseDir, fi.filename, fi, bundleNot)
+
+	p, err := newPageWithContent(f, s, false, func() (hugio.ReadSeekCloser, error) {
+		return hugio.NewReadSeekerNoOpCloserFromString(content), nil
+	}, fm)
+	if err != nil {
+		return nil, err
+	}
+
+	p.m.fromData = true
+
+	return p, nil
+}
+
+// dataPageFileInfo is the virtual content file of a page created from data.
+type dataPageFileInfo struct {
+	filename            string
+	path                string
+	baseDir             string
+	lang                string
+	translationBaseName string
+}
+
+var _ pathLangFileFi = (*dataPageFileInfo)(nil)
+
+func (fi *dataPageFileInfo) Name() string                { return filepath.Base(fi.filename) }
+func (fi *dataPageFileInfo) Size() int64                 { return 0 }
+func (fi *dataPageFileInfo) Mode() os.FileMode           { return 0444 }
+func (fi *dataPageFileInfo) ModTime() time.Time          { return time.Time{} }
+func (fi *dataPageFileInfo) IsDir() bool                 { return false }
+func (fi *dataPageFileInfo) Sys() interface{}            { return nil }
+func (fi *dataPageFileInfo) Filename() string            { return fi.filename }
+func (fi *dataPageFileInfo) Path() string                { return fi.path }
+func (fi *dataPageFileInfo) RealName() string            { return fi.Name() }
+func (fi *dataPageFileInfo) BaseDir() string             { return fi.baseDir }
+func (fi *dataPageFileInfo) Lang() string                { return fi.lang }
+func (fi *dataPageFileInfo) TranslationBaseName() string { return fi.translationBaseName }
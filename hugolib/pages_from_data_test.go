@@ -0,0 +1,161 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagesFromData(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent(
+		"products/_index.md", `---
+title: Products
+dataPages:
+  source: catalog/products
+---
+`,
+		"books/_index.md", `---
+title: Books
+dataPages:
+  resource: books.csv
+  markup: html
+---
+`,
+		"blog/post.md", `---
+title: Post
+tags: [red]
+---
+`,
+	)
+
+	b.WithData("catalog/products.yaml", `
+- title: Red Shoe
+  tags: [red, shoes]
+  weight: 1
+  price: 10
+  content: "Red **shoe**."
+- title: Blue Hat
+  path: hats/blue
+  weight: 2
+  params:
+    price: 20
+  content: "Blue hat."
+`)
+
+	b.WithSourceFile("assets/books.csv", `title,author,content
+Go,Kernighan,<p>The Go book.</p>
+`)
+
+	b.WithTemplatesAdded(
+		"_default/single.html", `{{ .Title }}|{{ .Params.price }}{{ .Params.author }}|{{ .Content }}|{{ .RelPermalink }}`,
+		"_default/list.html", `{{ .Title }}|{{ range .Pages }}{{ .Title }};{{ end }}`,
+		"index.html", `{{ range .Site.RegularPages }}{{ .Title }}|{{ end }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/products/red-shoe/index.html", "Red Shoe|10|<p>Red <strong>shoe</strong>.</p>\n|/products/red-shoe/")
+	b.AssertFileContent("public/products/hats/blue/index.html", "Blue Hat|20|<p>Blue hat.</p>\n|/products/hats/blue/")
+	b.AssertFileContent("public/books/go/index.html", "Go|Kernighan|<p>The Go book.</p>|/books/go/")
+	b.AssertFileContent("public/products/index.html", "Products|Red Shoe;Blue Hat;")
+	b.AssertFileContent("public/tags/red/index.html", "Red Shoe;", "Post;")
+	b.AssertFileContent("public/sitemap.xml", "/products/red-shoe/")
+
+	s := b.H.Sites[0]
+	assert.Len(s.RegularPages(), 4)
+}
+
+func TestPagesFromDataWithFrontMatterInContent(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("products/_index.md", `---
+title: Products
+dataPages:
+  source: products
+---
+`)
+	b.WithData("products.yaml", `
+- title: Shoe
+  weight: 1
+  price: 10
+  color: red
+  content: |
+    ---
+    title: Red Shoe
+    color: blue
+    size: 42
+    ---
+    The **shoe**.
+`)
+	b.WithTemplatesAdded(
+		"_default/single.html", `{{ .Title }}|{{ .Weight }}|{{ .Params.price }}|{{ .Params.color }}|{{ .Params.size }}|{{ .Content }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/products/shoe/index.html", "Red Shoe|1|10|blue|42|<p>The <strong>shoe</strong>.</p>")
+}
+
+func TestPagesFromDataRebuild(t *testing.T) {
+	b := newTestSitesBuilder(t).Running().WithSimpleConfigFile()
+
+	b.WithContent("products/_index.md", `---
+title: Products
+dataPages:
+  source: products
+---
+`)
+	b.WithData("products.json", `{"shoe": {"title": "Shoe"}}`)
+	b.WithTemplatesAdded(
+		"_default/single.html", `{{ .Title }}`,
+		"_default/list.html", `{{ .Title }}|{{ range .Pages }}{{ .Title }};{{ end }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/products/shoe/index.html", "Shoe")
+	b.AssertFileContent("public/products/index.html", "Products|Shoe;")
+
+	b.EditFiles("data/products.json", `{"shoe": {"title": "Red Shoe"}, "hat": {"title": "Hat"}}`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/products/shoe/index.html", "Red Shoe")
+	b.AssertFileContent("public/products/hat/index.html", "Hat")
+	b.AssertFileContent("public/products/index.html", "Products|Hat;Red Shoe;")
+}
+
+func TestDecodeDataPagesConfig(t *testing.T) {
+	assert := require.New(t)
+
+	c, err := decodeDataPagesConfig(map[string]interface{}{"source": "products"})
+	assert.NoError(err)
+	assert.Equal("products", c.Source)
+
+	_, err = decodeDataPagesConfig(map[string]interface{}{"markup": "html"})
+	assert.Error(err)
+
+	_, err = decodeDataPagesConfig(map[string]interface{}{"source": "products", "resource": "products.csv"})
+	assert.Error(err)
+}
@@ -96,3 +96,56 @@ URL: {{ $pag.URL }}
 		"0: 1/1  true")
 
 }
+
+func TestPaginatorNamed(t *testing.T) {
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("blog/_index.md", `---
+title: Blog
+paginate: 2
+---
+`)
+	for i := 1; i <= 5; i++ {
+		b.WithContent(fmt.Sprintf("blog/page%d.md", i), fmt.Sprintf(`---
+title: Page %d
+weight: %d
+---
+`, i, i))
+	}
+
+	b.WithData("comments.yaml", `
+- author: a
+- author: b
+- author: c
+- author: d
+- author: e
+`)
+
+	b.WithTemplatesAdded("_default/list.html", `
+{{ $pag := .Paginator }}
+Pages: {{ $pag.PageNumber }}/{{ $pag.TotalPages }}|{{ range $pag.Pages }}{{ .Title }}|{{ end }}
+{{ $comments := .Paginate .Site.Data.comments (dict "name" "comments" "pagerSize" 3) }}
+Comments: {{ $comments.PageNumber }}/{{ $comments.TotalPages }}|{{ range $comments.Items }}{{ .author }}|{{ end }}
+{{ with $comments.Next }}Next comments: {{ .URL }}{{ end }}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/blog/index.html",
+		"Pages: 1/3|Page 1|Page 2|",
+		"Comments: 1/2|a|b|c|",
+		"Next comments: /blog/comments/2/",
+	)
+
+	b.AssertFileContent("public/blog/page/2/index.html",
+		"Pages: 2/3|Page 3|Page 4|",
+		"Comments: 1/2|a|b|c|",
+	)
+
+	b.AssertFileContent("public/blog/comments/2/index.html",
+		"Pages: 1/3|Page 1|Page 2|",
+		"Comments: 2/2|d|e|",
+	)
+
+	b.AssertFileContent("public/blog/comments/1/index.html", "refresh")
+}
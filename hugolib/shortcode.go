@@ -360,7 +360,9 @@ func renderShortcode(
 				Cfg:          p.Language(),
 				DocumentID:   p.File().UniqueID(),
 				DocumentName: p.File().Path(),
-				Config:       p.getRenderingConfig()})
+				Config:       p.getRenderingConfig(),
+				Page:         p,
+				RenderHooks:  s.renderHooks()})
 
 			// If the type is “” (unknown) or “markdown”, we assume the markdown
 			// generation has been performed. Given the input: `a line`, markdown
@@ -373,7 +375,7 @@ func renderShortcode(
 			// 2   If inner does not have a newline, strip the wrapping <p> block and
 			//     the newline.
 			switch p.m.markup {
-			case "", "markdown":
+			case "", "markdown", "commonmark":
 				if match, _ := regexp.MatchString(innerNewlineRegexp, inner); !match {
 					cleaner, err := regexp.Compile(innerCleanupRegexp)
 
@@ -432,6 +434,25 @@ func (s *shortcodeHandler) renderShortcodesForPage(p *pageState, f output.Format
 	return rendered, hasVariants, nil
 }
 
+var shortcodeHeadingIDRe = regexp.MustCompile(`(<h[1-6][^>]*\sid=")([^"]+)(")`)
+
+// uniqueHeadingIDs makes the IDs of the headings in the rendered shortcodes
+// unique and reserves them in ids, in the order the shortcodes appear in the
+// page.
+func (s *shortcodeHandler) uniqueHeadingIDs(rendered map[string]string, ids *helpers.HeadingIDs) {
+	for _, v := range s.shortcodes {
+		content, found := rendered[v.placeholder]
+		if !found || !strings.Contains(content, " id=") {
+			continue
+		}
+
+		rendered[v.placeholder] = shortcodeHeadingIDRe.ReplaceAllStringFunc(content, func(m string) string {
+			parts := shortcodeHeadingIDRe.FindStringSubmatch(m)
+			return parts[1] + ids.Unique(parts[2]) + parts[3]
+		})
+	}
+}
+
 var errShortCodeIllegalState = errors.New("Illegal shortcode state")
 
 func (s *shortcodeHandler) parseError(err error, input []byte, pos int) error {
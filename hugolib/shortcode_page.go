@@ -16,6 +16,7 @@ package hugolib
 import (
 	"html/template"
 
+	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/resources/page"
 )
 
@@ -54,3 +55,9 @@ func (p *pageForShortcode) TableOfContents() template.HTML {
 	p.p.enablePlaceholders()
 	return p.toc
 }
+
+// TOC returns an empty table of contents, as the headings are not known
+// until the content the shortcode is a part of is rendered.
+func (p *pageForShortcode) TOC() *helpers.TableOfContents {
+	return &helpers.TableOfContents{}
+}
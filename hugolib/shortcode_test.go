@@ -145,6 +145,53 @@ func TestShortcodeRelated(t *testing.T) {
 	CheckShortCodeMatch(t, "{{< a >}}", "0", wt)
 }
 
+func TestShortcodeRelatedTFIDF(t *testing.T) {
+	t.Parallel()
+
+	config := `
+baseURL = "https://example.com"
+
+[related]
+threshold = 10
+includeNewer = true
+[[related.indices]]
+name = "content"
+type = "tfidf"
+weight = 100
+`
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+
+	b.WithContent("posts/hugo.md", `---
+title: "Hugo"
+---
+
+Hugo is a static site generator written in Go.
+`, "posts/cooking.md", `---
+title: "Cooking"
+---
+
+Cooking pasta with tomato sauce.
+`, "other/sidebar.md", `---
+title: "Sidebar"
+---
+
+{{< related "generator" >}}
+`)
+
+	// Building the index for all pages renders the content of the sidebar,
+	// which searches another index.
+	b.WithTemplatesAdded(
+		"shortcodes/related.html", `Related posts: {{ range (where .Page.Site.RegularPages "Section" "posts").RelatedTo (keyVals "content" (.Get 0)) }}{{ .Title }}|{{ end }}`,
+		"_default/single.html", `{{ .Content }}|Related: {{ range .Site.RegularPages.Related . }}{{ .Title }}|{{ end }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/other/sidebar/index.html", "Related posts: Hugo|")
+	b.AssertFileContent("public/posts/hugo/index.html", "Related: ")
+}
+
 func TestShortcodeInnerMarkup(t *testing.T) {
 	t.Parallel()
 	wt := func(tem tpl.TemplateHandler) error {
@@ -1165,3 +1212,36 @@ title: "Hugo Rocks!"
 		"test/hello: test/hello",
 	)
 }
+
+func TestShortcodeHeadingIDs(t *testing.T) {
+	t.Parallel()
+
+	for _, idType := range []string{"blackfriday", "github"} {
+		for _, markup := range []string{"markdown", "commonmark"} {
+			builder := newTestSitesBuilder(t).WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "https://example.org"
+autoHeadingIDType = %q
+`, idType))
+
+			builder.WithContent("page.md", fmt.Sprintf(`---
+title: "Headings"
+markup: %q
+---
+
+{{< heading >}}
+
+## Intro
+
+## Intro
+`, markup)).WithTemplatesAdded(
+				"layouts/_default/single.html", `{{ .Content }}`,
+				"layouts/shortcodes/heading.html", `<h2 id="intro">Shortcode Intro</h2>`).Build(BuildCfg{})
+
+			builder.AssertFileContent("public/page/index.html",
+				`<h2 id="intro">Shortcode Intro</h2>`,
+				`<h2 id="intro-1">Intro</h2>`,
+				`<h2 id="intro-2">Intro</h2>`,
+			)
+		}
+	}
+}
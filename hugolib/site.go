@@ -167,19 +167,26 @@ type siteConfigHolder struct {
 	timeout          time.Duration
 	hasCJKLanguage   bool
 	enableEmoji      bool
+
+	// The plural names of the taxonomies with hierarchical terms.
+	hierarchicalTaxonomies map[string]bool
 }
 
 // Lazily loaded site dependencies.
 type siteInit struct {
 	prevNext          *lazy.Init
 	prevNextInSection *lazy.Init
+	termPositions     *lazy.Init
 	menus             *lazy.Init
+	renderHooks       *lazy.Init
 }
 
 func (init *siteInit) Reset() {
 	init.prevNext.Reset()
 	init.prevNextInSection.Reset()
+	init.termPositions.Reset()
 	init.menus.Reset()
+	init.renderHooks.Reset()
 }
 
 func (s *Site) initInit(init *lazy.Init, pctx pageContext) {
@@ -217,7 +224,7 @@ func (s *Site) prepareInits() {
 	s.init.prevNextInSection = init.Branch(func() (interface{}, error) {
 		var rootSection []int
 		for i, p1 := range s.workAllPages {
-			if p1.IsPage() && p1.Section() == "" {
+			if p1.IsPage() && p1.Section() == "" && !p1.m.noList() {
 				rootSection = append(rootSection, i)
 			}
 			if p1.IsSection() {
@@ -256,11 +263,33 @@ func (s *Site) prepareInits() {
 		return nil, nil
 	})
 
+	s.init.termPositions = init.Branch(func() (interface{}, error) {
+		// Index the pages of every taxonomy term once, so looking up a page's
+		// position in a term is cheap.
+		positions := make(map[string]map[string]map[page.Page]int)
+		for plural, taxonomy := range s.Taxonomies {
+			terms := make(map[string]map[page.Page]int)
+			for key, pages := range taxonomy {
+				index := make(map[page.Page]int)
+				for i, wp := range pages {
+					index[wp.Page] = i
+				}
+				terms[key] = index
+			}
+			positions[plural] = terms
+		}
+		return positions, nil
+	})
+
 	s.init.menus = init.Branch(func() (interface{}, error) {
 		s.assembleMenus()
 		return nil, nil
 	})
 
+	s.init.renderHooks = init.Branch(func() (interface{}, error) {
+		return s.lookupRenderHooks(), nil
+	})
+
 }
 
 // Build stats for a given site.
@@ -412,6 +441,11 @@ func newSite(cfg deps.DepsCfg) (*Site, error) {
 
 	taxonomies := cfg.Language.GetStringMapString("taxonomies")
 
+	hierarchicalTaxonomies := make(map[string]bool)
+	for _, plural := range cfg.Language.GetStringSlice("hierarchicalTaxonomies") {
+		hierarchicalTaxonomies[plural] = true
+	}
+
 	var relatedContentConfig related.Config
 
 	if cfg.Language.IsSet("related") {
@@ -426,6 +460,10 @@ func newSite(cfg deps.DepsCfg) (*Site, error) {
 		}
 	}
 
+	if relatedContentConfig.Language == "" {
+		relatedContentConfig.Language = cfg.Language.Lang
+	}
+
 	titleFunc := helpers.GetTitleFunc(cfg.Language.GetString("titleCaseStyle"))
 
 	frontMatterHandler, err := pagemeta.NewFrontmatterHandler(cfg.Logger, cfg.Cfg)
@@ -434,11 +472,12 @@ func newSite(cfg deps.DepsCfg) (*Site, error) {
 	}
 
 	siteConfig := siteConfigHolder{
-		sitemap:          config.DecodeSitemap(config.Sitemap{Priority: -1, Filename: "sitemap.xml"}, cfg.Language.GetStringMap("sitemap")),
-		taxonomiesConfig: taxonomies,
-		timeout:          time.Duration(cfg.Language.GetInt("timeout")) * time.Millisecond,
-		hasCJKLanguage:   cfg.Language.GetBool("hasCJKLanguage"),
-		enableEmoji:      cfg.Language.Cfg.GetBool("enableEmoji"),
+		sitemap:                config.DecodeSitemap(config.Sitemap{Priority: -1, Filename: "sitemap.xml"}, cfg.Language.GetStringMap("sitemap")),
+		taxonomiesConfig:       taxonomies,
+		hierarchicalTaxonomies: hierarchicalTaxonomies,
+		timeout:                time.Duration(cfg.Language.GetInt("timeout")) * time.Millisecond,
+		hasCJKLanguage:         cfg.Language.GetBool("hasCJKLanguage"),
+		enableEmoji:            cfg.Language.Cfg.GetBool("enableEmoji"),
 	}
 
 	s := &Site{
@@ -560,6 +599,7 @@ type SiteInfo struct {
 	language                       *langs.Language
 	defaultContentLanguageInSubdir bool
 	sectionPagesMenu               string
+	sectionPagesMenuTree           bool
 }
 
 func (s *SiteInfo) Pages() page.Pages {
@@ -766,6 +806,10 @@ func (s *siteRefLinker) refLink(ref string, source interface{}, relative bool, o
 			return s.notFoundURL, nil
 		}
 
+		if ps, ok := p.(*pageState); ok {
+			ps.addRefTarget(target)
+		}
+
 		var permalinker Permalinker = target
 
 		if outputFormat != "" {
@@ -939,6 +983,7 @@ func (s *Site) processPartial(events []fsnotify.Event) (whatChanged, error) {
 		dataChanged         = []fsnotify.Event{}
 		i18nChanged         = []fsnotify.Event{}
 		shortcodesChanged   = make(map[string]bool)
+		renderHooksChanged  bool
 		sourceFilesChanged  = make(map[string]bool)
 
 		// prevent spamming the log on changes
@@ -963,6 +1008,10 @@ func (s *Site) processPartial(events []fsnotify.Event) (whatChanged, error) {
 				shortcode = strings.TrimSuffix(shortcode, filepath.Ext(shortcode))
 				shortcodesChanged[shortcode] = true
 			}
+
+			if isRenderHookTemplate(ev.Name) {
+				renderHooksChanged = true
+			}
 		}
 		if s.isDataDirEvent(ev) {
 			logger.Println("Data changed", ev)
@@ -1006,6 +1055,10 @@ func (s *Site) processPartial(events []fsnotify.Event) (whatChanged, error) {
 				return whatChanged{}, err
 			}
 		}
+
+		for _, site := range sites {
+			site.init.renderHooks.Reset()
+		}
 	}
 
 	if len(dataChanged) > 0 {
@@ -1028,7 +1081,7 @@ func (s *Site) processPartial(events []fsnotify.Event) (whatChanged, error) {
 				removed = true
 			}
 		}
-		if removed && IsContentFile(ev.Name) {
+		if removed && isContentExtFunc(h.isContentExt).isContentFile(ev.Name) {
 			h.removePageByFilename(ev.Name)
 		}
 
@@ -1049,6 +1102,14 @@ func (s *Site) processPartial(events []fsnotify.Event) (whatChanged, error) {
 		}
 	}
 
+	if renderHooksChanged {
+		// The render hooks are applied when the content is rendered, so
+		// all pages rendered from markup need to be reprocessed.
+		for _, p := range h.findPagesWithMarkup() {
+			contentFilesChanged = append(contentFilesChanged, p.File().Filename())
+		}
+	}
+
 	if len(sourceReallyChanged) > 0 || len(contentFilesChanged) > 0 {
 		var filenamesChanged []string
 		for _, e := range sourceReallyChanged {
@@ -1067,7 +1128,7 @@ func (s *Site) processPartial(events []fsnotify.Event) (whatChanged, error) {
 	}
 
 	changed := whatChanged{
-		source: len(sourceChanged) > 0 || len(shortcodesChanged) > 0,
+		source: len(sourceChanged) > 0 || len(shortcodesChanged) > 0 || renderHooksChanged,
 		other:  len(tmplChanged) > 0 || len(i18nChanged) > 0 || len(dataChanged) > 0,
 		files:  sourceFilesChanged,
 	}
@@ -1194,6 +1255,13 @@ func (s *Site) initialize() (err error) {
 	return s.initializeSiteInfo()
 }
 
+// isContentExt reports whether files with the given extension, without the
+// dot, are content files in this site. This includes the extensions
+// configured for the external markup converters.
+func (s *Site) isContentExt(ext string) bool {
+	return isDefaultContentExt(ext) || s.ContentSpec.IsExternalConverterExtension(ext)
+}
+
 // HomeAbsURL is a convenience method giving the absolute URL to the home page.
 func (s *SiteInfo) HomeAbsURL() string {
 	base := ""
@@ -1269,6 +1337,7 @@ func (s *Site) initializeSiteInfo() error {
 		Languages:                      languages,
 		defaultContentLanguageInSubdir: defaultContentInSubDir,
 		sectionPagesMenu:               lang.GetString("sectionPagesMenu"),
+		sectionPagesMenuTree:           lang.GetBool("sectionPagesMenuTree"),
 		BuildDrafts:                    s.Cfg.GetBool("buildDrafts"),
 		canonifyURLs:                   s.Cfg.GetBool("canonifyURLs"),
 		relativeURLs:                   s.Cfg.GetBool("relativeURLs"),
@@ -1377,7 +1446,7 @@ func (s *Site) readAndProcessContent(filenames ...string) error {
 		handler = mainHandler
 	}
 
-	c := newCapturer(s.Log, sourceSpec, handler, bundleMap, filenames...)
+	c := newCapturer(s.Log, sourceSpec, s.h.isContentExt, handler, bundleMap, filenames...)
 
 	err1 := c.capture()
 
@@ -1399,37 +1468,91 @@ func (s *Site) getMenusFromConfig() navigation.Menus {
 
 	if menus := s.language.GetStringMap("menus"); menus != nil {
 		for name, menu := range menus {
-			m, err := cast.ToSliceE(menu)
-			if err != nil {
-				s.Log.ERROR.Printf("unable to process menus in site config\n")
-				s.Log.ERROR.Println(err)
-			} else {
-				for _, entry := range m {
-					s.Log.DEBUG.Printf("found menu: %q, in site config\n", name)
-
-					menuEntry := navigation.MenuEntry{Menu: name}
-					ime, err := cast.ToStringMapE(entry)
-					if err != nil {
-						s.Log.ERROR.Printf("unable to process menus in site config\n")
-						s.Log.ERROR.Println(err)
-					}
+			s.addMenuEntries(ret, name, menu, "site config")
+		}
+	}
+	return ret
+}
 
-					menuEntry.MarshallMap(ime)
-					// TODO(bep) clean up all of this
-					menuEntry.ConfiguredURL = s.Info.createNodeMenuEntryURL(menuEntry.ConfiguredURL)
+// getMenusFromData returns the menus defined in data/menus. Every file in that
+// folder holds a list of entries for the menu with the same name as the file,
+// e.g. data/menus/main.yaml. Menus in a sub folder named after a language,
+// e.g. data/menus/fr/main.yaml, replace the shared menu of the same name for
+// that language.
+func (s *Site) getMenusFromData() navigation.Menus {
 
-					if ret[name] == nil {
-						ret[name] = navigation.Menu{}
-					}
-					ret[name] = ret[name].Add(&menuEntry)
-				}
+	ret := navigation.Menus{}
+
+	data := s.h.Data()
+	if data == nil {
+		return ret
+	}
+
+	menus, ok := data["menus"].(map[string]interface{})
+	if !ok {
+		return ret
+	}
+
+	var languageMenus map[string]interface{}
+
+	for name, menu := range menus {
+		if m, ok := menu.(map[string]interface{}); ok {
+			if name == s.language.Lang {
+				languageMenus = m
 			}
+			continue
 		}
-		return ret
+		s.addMenuEntries(ret, name, menu, "data/menus")
 	}
+
+	for name, menu := range languageMenus {
+		delete(ret, name)
+		s.addMenuEntries(ret, name, menu, "data/menus/"+s.language.Lang)
+	}
+
 	return ret
 }
 
+func (s *Site) addMenuEntries(menus navigation.Menus, name string, menu interface{}, source string) {
+	m, err := cast.ToSliceE(menu)
+	if err != nil {
+		s.Log.ERROR.Printf("unable to process menus in %s\n", source)
+		s.Log.ERROR.Println(err)
+		return
+	}
+
+	for _, entry := range m {
+		s.Log.DEBUG.Printf("found menu: %q, in %s\n", name, source)
+
+		menuEntry := navigation.MenuEntry{Menu: name}
+		ime, err := cast.ToStringMapE(entry)
+		if err != nil {
+			s.Log.ERROR.Printf("unable to process menus in %s\n", source)
+			s.Log.ERROR.Println(err)
+		}
+
+		menuEntry.MarshallMap(ime)
+		// TODO(bep) clean up all of this
+		menuEntry.ConfiguredURL = s.Info.createNodeMenuEntryURL(menuEntry.ConfiguredURL)
+
+		if menuEntry.PageRef != "" {
+			p, err := s.getPageNew(nil, menuEntry.PageRef)
+			if err != nil {
+				s.Log.ERROR.Printf("unable to resolve pageRef %q in menu %q in %s: %s\n", menuEntry.PageRef, name, source, err)
+			} else if p == nil {
+				s.Log.WARN.Printf("pageRef %q in menu %q in %s not found\n", menuEntry.PageRef, name, source)
+			} else {
+				menuEntry.Page = p
+			}
+		}
+
+		if menus[name] == nil {
+			menus[name] = navigation.Menu{}
+		}
+		menus[name] = menus[name].Add(&menuEntry)
+	}
+}
+
 func (s *SiteInfo) createNodeMenuEntryURL(in string) string {
 
 	if !strings.HasPrefix(in, "/") {
@@ -1461,25 +1584,48 @@ func (s *Site) assembleMenus() {
 		}
 	}
 
+	// add menu entries from data/menus, these may not redefine config entries
+	menuData := s.getMenusFromData()
+	for name, menu := range menuData {
+		for _, me := range menu {
+			if _, ok := flat[twoD{name, me.KeyName()}]; ok {
+				s.SendError(errors.Errorf("duplicate menu entry with identifier %q in menu %q in data/menus", me.KeyName(), name))
+				continue
+			}
+			flat[twoD{name, me.KeyName()}] = me
+		}
+	}
+
 	sectionPagesMenu := s.Info.sectionPagesMenu
 
 	if sectionPagesMenu != "" {
 		for _, p := range s.workAllPages {
-			if p.Kind() == page.KindSection {
-				// From Hugo 0.22 we have nested sections, but until we get a
-				// feel of how that would work in this setting, let us keep
-				// this menu for the top level only.
-				id := p.Section()
-				if _, ok := flat[twoD{sectionPagesMenu, id}]; ok {
-					continue
-				}
+			if p.Kind() != page.KindSection {
+				continue
+			}
+
+			// By default only the top level sections are added. In tree mode
+			// the full section tree is added, with the parent section's path
+			// as the menu parent.
+			sections := p.SectionsEntries()
+			if len(sections) > 1 && !s.Info.sectionPagesMenuTree {
+				continue
+			}
 
-				me := navigation.MenuEntry{Identifier: id,
-					Name:   p.LinkTitle(),
-					Weight: p.Weight(),
-					Page:   p}
-				flat[twoD{sectionPagesMenu, me.KeyName()}] = &me
+			id := p.SectionsPath()
+			if _, ok := flat[twoD{sectionPagesMenu, id}]; ok {
+				continue
 			}
+
+			me := navigation.MenuEntry{Identifier: id,
+				Menu:   sectionPagesMenu,
+				Name:   p.LinkTitle(),
+				Weight: p.Weight(),
+				Page:   p}
+			if len(sections) > 1 {
+				me.Parent = path.Join(sections[:len(sections)-1]...)
+			}
+			flat[twoD{sectionPagesMenu, me.KeyName()}] = &me
 		}
 	}
 
@@ -1576,6 +1722,7 @@ func (s *Site) assembleTaxonomies() error {
 	for singular, plural := range taxonomies {
 		parent := s.taxonomyNodes.GetOrCreate(plural, "")
 		parent.singular = singular
+		parent.hierarchical = s.siteCfg.hierarchicalTaxonomies[plural]
 
 		addTaxonomy := func(plural, term string, weight int, p page.Page) {
 			key := s.getTaxonomyKey(term)
@@ -1591,7 +1738,32 @@ func (s *Site) assembleTaxonomies() error {
 			parent.UpdateFromPage(w.Page)
 		}
 
+		// In a hierarchical taxonomy, the page is also added to the
+		// ancestors of the term, but only once for every term.
+		addHierarchicalTaxonomy := func(plural, term string, weight int, p page.Page, seen map[string]bool) {
+			var parentTerm *taxonomyNodeInfo
+			for _, t := range hierarchicalTerms(term) {
+				n := s.taxonomyNodes.GetOrCreate(plural, t)
+				if parentTerm == nil {
+					parent.addChild(n)
+				} else {
+					n.parentTerm = parentTerm
+					parentTerm.addChild(n)
+				}
+				parentTerm = n
+
+				if key := s.getTaxonomyKey(t); !seen[key] {
+					seen[key] = true
+					addTaxonomy(plural, t, weight, p)
+				}
+			}
+		}
+
 		for _, p := range s.workAllPages {
+			if p.m.noList() {
+				continue
+			}
+
 			vals := getParam(p, plural, false)
 
 			w := getParamToLower(p, plural+"_weight")
@@ -1602,12 +1774,19 @@ func (s *Site) assembleTaxonomies() error {
 			}
 
 			if vals != nil {
+				if v, ok := vals.(string); ok {
+					vals = []string{v}
+				}
+
 				if v, ok := vals.([]string); ok {
+					seen := make(map[string]bool)
 					for _, idx := range v {
-						addTaxonomy(plural, idx, weight, p)
+						if parent.hierarchical {
+							addHierarchicalTaxonomy(plural, idx, weight, p, seen)
+						} else {
+							addTaxonomy(plural, idx, weight, p)
+						}
 					}
-				} else if v, ok := vals.(string); ok {
-					addTaxonomy(plural, v, weight, p)
 				} else {
 					s.Log.ERROR.Printf("Invalid %s in %q\n", plural, p.pathOrTitle())
 				}
@@ -1622,6 +1801,31 @@ func (s *Site) assembleTaxonomies() error {
 	return nil
 }
 
+// linkTaxonomyTerms sets the parent of the term pages in the hierarchical
+// taxonomies, which is the page of the term above or, for the top level
+// terms, the taxonomy's terms page.
+func (s *Site) linkTaxonomyTerms() {
+	for _, p := range s.workAllPages {
+		if p.Kind() != page.KindTaxonomy {
+			continue
+		}
+
+		info := p.getTaxonomyNodeInfo()
+		if info == nil || info.parent == nil || !info.parent.hierarchical {
+			continue
+		}
+
+		parent := info.parent
+		if info.parentTerm != nil {
+			parent = info.parentTerm
+		}
+
+		if pp, ok := parent.owner.Page.(*pageState); ok {
+			p.parent = pp
+		}
+	}
+}
+
 // Prepare site for a new full build.
 func (s *Site) resetBuildState() {
 	s.relatedDocsHandler = s.relatedDocsHandler.Clone()
@@ -0,0 +1,286 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gohugoio/hugo/resources/page"
+)
+
+// Matches the destination of inline Markdown links and of HTML links.
+var linkDestinationRe = regexp.MustCompile(`\]\(\s*<?([^)\s>]+)|href="([^"]+)"`)
+
+// linkGraph holds the pages linking to every page in all sites. It is built
+// before rendering from the ref and relref shortcodes in the content files
+// and, if backlinks.markdownLinks is enabled, the internal links in the
+// Markdown content. It also includes the links resolved with ref or relref
+// in any earlier render, e.g. from Page.Ref, Page.RelRef and the ref and
+// relref template funcs. These are only known after rendering, so the graph
+// is rebuilt after every render and the pages whose backlinks changed are
+// rendered again.
+type linkGraph struct {
+	backlinks map[*pageState]page.Pages
+
+	// The backlinks of every page as a string, keyed by the page's linkKey.
+	// Used to detect the pages whose backlinks changed in a rebuild.
+	signatures map[string]string
+}
+
+// Backlinks returns the pages linking to p.
+func (p *pageState) Backlinks() page.Pages {
+	g := p.s.h.linkGraph
+	if g == nil {
+		return nil
+	}
+	return g.backlinks[p]
+}
+
+// linkKey identifies a page across rebuilds.
+func (p *pageState) linkKey() string {
+	return p.Lang() + ":" + p.Kind() + ":" + p.sourceRef()
+}
+
+// addRefTarget records that p links to target with ref or relref.
+func (p *pageState) addRefTarget(target page.Page) {
+	ps, ok := target.(*pageState)
+	if !ok || ps == p {
+		return
+	}
+
+	key := ps.linkKey()
+
+	p.refTargetsMu.Lock()
+	defer p.refTargetsMu.Unlock()
+
+	if p.refTargets == nil {
+		p.refTargets = make(map[string]bool)
+	}
+	p.refTargets[key] = true
+}
+
+func (p *pageState) getRefTargets() []string {
+	p.refTargetsMu.Lock()
+	defer p.refTargetsMu.Unlock()
+
+	keys := make([]string, 0, len(p.refTargets))
+	for key := range p.refTargets {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// buildLinkGraph builds the link graph for all sites and reports whether the
+// backlinks of any page changed since the previous graph.
+func (h *HugoSites) buildLinkGraph() (bool, error) {
+	markdownLinks := h.Cfg.GetBool("backlinks.markdownLinks")
+
+	var permalinks map[string]*pageState
+	if markdownLinks {
+		var err error
+		if permalinks, err = h.pagesByRelPermalink(); err != nil {
+			return false, err
+		}
+	}
+
+	pagesByLinkKey := make(map[string]*pageState)
+	for _, s := range h.Sites {
+		for _, p := range s.workAllPages {
+			pagesByLinkKey[p.linkKey()] = p
+		}
+	}
+
+	links := make(map[*pageState]map[*pageState]bool)
+	add := func(source, target *pageState) {
+		if target == nil || target == source {
+			return
+		}
+		if links[target] == nil {
+			links[target] = make(map[*pageState]bool)
+		}
+		links[target][source] = true
+	}
+
+	for _, s := range h.Sites {
+		for _, p := range s.workAllPages {
+			for _, key := range p.getRefTargets() {
+				add(p, pagesByLinkKey[key])
+			}
+
+			if p.shortcodeState != nil {
+				for _, sc := range p.shortcodeState.shortcodes {
+					h.addShortcodeRefs(p, sc, add)
+				}
+			}
+
+			if markdownLinks {
+				for _, m := range linkDestinationRe.FindAllStringSubmatch(p.RawContent(), -1) {
+					dest := m[1]
+					if dest == "" {
+						dest = m[2]
+					}
+					add(p, p.resolveLinkDestination(dest, permalinks))
+				}
+			}
+		}
+	}
+
+	g := &linkGraph{
+		backlinks:  make(map[*pageState]page.Pages),
+		signatures: make(map[string]string),
+	}
+
+	for target, sources := range links {
+		pages := make(page.Pages, 0, len(sources))
+		for source := range sources {
+			pages = append(pages, source)
+		}
+		page.SortByDefault(pages)
+		g.backlinks[target] = pages
+
+		keys := make([]string, len(pages))
+		for i, source := range pages {
+			keys[i] = source.(*pageState).linkKey()
+		}
+		g.signatures[target.linkKey()] = strings.Join(keys, "|")
+	}
+
+	// Only the pages whose backlinks changed need to be rendered again
+	// after a render, and in fast render mode, only the changed and
+	// recently visited pages are rendered, so make sure the pages whose
+	// backlinks changed are also rendered.
+	var changed bool
+	if h.linkGraph != nil {
+		for _, s := range h.Sites {
+			for _, p := range s.workAllPages {
+				key := p.linkKey()
+				p.backlinksChanged = g.signatures[key] != h.linkGraph.signatures[key]
+				changed = changed || p.backlinksChanged
+			}
+		}
+	}
+
+	h.linkGraph = g
+
+	return changed, nil
+}
+
+// addShortcodeRefs adds the pages referenced by sc, or by any shortcode
+// nested in it, if it is a ref or relref shortcode.
+func (h *HugoSites) addShortcodeRefs(p *pageState, sc *shortcode, add func(source, target *pageState)) {
+	if sc.name == "ref" || sc.name == "relref" {
+		add(p, h.resolveShortcodeRef(p, sc.params))
+	}
+
+	for _, inner := range sc.inner {
+		if nested, ok := inner.(*shortcode); ok {
+			h.addShortcodeRefs(p, nested, add)
+		}
+	}
+}
+
+// resolveShortcodeRef resolves the ref or relref shortcode params to a page
+// the same way as the ref and relref functions. Any errors will be reported
+// when the shortcode is rendered.
+func (h *HugoSites) resolveShortcodeRef(p *pageState, params interface{}) *pageState {
+	var ref, lang string
+
+	switch v := params.(type) {
+	case []string:
+		if len(v) > 0 {
+			ref = v[0]
+		}
+	case map[string]string:
+		ref, lang = v["path"], v["lang"]
+	}
+
+	s := p.s
+	if lang != "" && lang != s.Lang() {
+		s = nil
+		for _, ss := range h.Sites {
+			if ss.Lang() == lang {
+				s = ss
+			}
+		}
+		if s == nil {
+			return nil
+		}
+	}
+
+	refURL, err := url.Parse(filepath.ToSlash(ref))
+	if err != nil || refURL.Path == "" {
+		return nil
+	}
+
+	target, err := s.getPageNew(p, refURL.Path)
+	if err != nil || target == nil {
+		return nil
+	}
+
+	ps, _ := target.(*pageState)
+	return ps
+}
+
+// resolveLinkDestination resolves an internal link in the content of p to the
+// page it links to, if any.
+func (p *pageState) resolveLinkDestination(dest string, permalinks map[string]*pageState) *pageState {
+	if strings.Contains(dest, "{{") {
+		// A shortcode, e.g. a relref.
+		return nil
+	}
+
+	u, err := url.Parse(dest)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return nil
+	}
+
+	link := u.Path
+	if !strings.HasPrefix(link, "/") {
+		// Relative to the page's URL.
+		base := "/"
+		if formats := p.OutputFormats(); len(formats) > 0 {
+			base = formats[0].RelPermalink()
+		}
+		if !strings.HasSuffix(base, "/") {
+			base = path.Dir(base)
+		}
+		link = path.Join(base, link)
+	}
+
+	return permalinks[strings.TrimSuffix(link, "/")]
+}
+
+// pagesByRelPermalink maps the relative permalinks in all output formats of
+// the pages in all sites, without any trailing slash, to their pages.
+func (h *HugoSites) pagesByRelPermalink() (map[string]*pageState, error) {
+	m := make(map[string]*pageState)
+
+	for _, s := range h.Sites {
+		for _, p := range s.workAllPages {
+			if err := p.initPage(); err != nil {
+				return nil, err
+			}
+
+			for _, f := range p.OutputFormats() {
+				m[strings.TrimSuffix(f.RelPermalink(), "/")] = p
+			}
+		}
+	}
+
+	return m, nil
+}
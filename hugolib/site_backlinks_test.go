@@ -0,0 +1,128 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+)
+
+const backlinksTemplate = `{{ .Title }}|Backlinks: {{ range .Backlinks }}{{ .Title }}|{{ end }}END`
+
+func TestBacklinks(t *testing.T) {
+	t.Parallel()
+
+	config := `
+baseURL = "https://example.com"
+
+[backlinks]
+markdownLinks = true
+`
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+
+	b.WithContent("docs/_index.md", `---
+title: "Docs"
+---
+`, "docs/p1.md", `---
+title: "P1"
+---
+
+See [P2]({{< relref "p2.md" >}}) and {{< ref path="/docs/p3.md" >}}.
+`, "docs/p2.md", `---
+title: "P2"
+---
+
+See [P3](../p3/) and [Docs](/docs/).
+`, "docs/p3.md", `---
+title: "P3"
+---
+
+See [Hugo](https://gohugo.io/) and [myself](#top).
+`)
+
+	b.WithTemplates("_default/single.html", backlinksTemplate, "_default/list.html", backlinksTemplate)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/docs/p1/index.html", "P1|Backlinks: END")
+	b.AssertFileContent("public/docs/p2/index.html", "P2|Backlinks: P1|END")
+	b.AssertFileContent("public/docs/p3/index.html", "P3|Backlinks: P1|P2|END")
+	b.AssertFileContent("public/docs/index.html", "Docs|Backlinks: P2|END")
+}
+
+func TestBacklinksRebuild(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	b.WithContent("p1.md", `---
+title: "P1"
+---
+`, "p2.md", `---
+title: "P2"
+---
+`)
+
+	b.WithTemplates("_default/single.html", backlinksTemplate, "_default/list.html", backlinksTemplate)
+
+	b.Running().Build(BuildCfg{})
+
+	b.AssertFileContent("public/p2/index.html", "P2|Backlinks: END")
+
+	b.EditFiles("content/p1.md", `---
+title: "P1"
+---
+
+{{< ref "p2.md" >}}
+`)
+
+	// P2 is neither changed nor visited, but its backlinks changed.
+	b.Build(BuildCfg{RecentlyVisited: map[string]bool{"/": true}})
+
+	b.AssertFileContent("public/p2/index.html", "P2|Backlinks: P1|END")
+}
+
+func TestBacklinksRefInTemplates(t *testing.T) {
+	t.Parallel()
+
+	b := newTestSitesBuilder(t).WithSimpleConfigFile()
+
+	// The pages are rendered in title order, so A1 is rendered before the
+	// pages linking to it.
+	b.WithContent("a1.md", `---
+title: "A1"
+---
+
+{{< see "z2.md" >}}
+`, "z2.md", `---
+title: "Z2"
+---
+`, "z3.md", `---
+title: "Z3"
+seeAlso: "a1.md"
+---
+`)
+
+	b.WithTemplatesAdded(
+		"shortcodes/see.html", `{{ relref .Page (.Get 0) }}`,
+		"_default/single.html", `{{ with .Params.seeAlso }}{{ $.RelRef (dict "path" .) }}|{{ end }}{{ .Content }}|`+backlinksTemplate,
+		"_default/list.html", backlinksTemplate,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/a1/index.html", "A1|Backlinks: Z3|END")
+	b.AssertFileContent("public/z2/index.html", "Z2|Backlinks: A1|END")
+	b.AssertFileContent("public/z3/index.html", "Z3|Backlinks: END")
+}
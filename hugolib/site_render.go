@@ -75,7 +75,7 @@ func (s *Site) renderPages(ctx *siteRenderContext) error {
 
 	cfg := ctx.cfg
 
-	if !cfg.PartialReRender && ctx.outIdx == 0 && len(s.headlessPages) > 0 {
+	if !cfg.PartialReRender && ctx.outIdx == 0 {
 		wg.Add(1)
 		go headlessPagesPublisher(s, wg)
 	}
@@ -105,11 +105,18 @@ L:
 	return nil
 }
 
+// headlessPagesPublisher publishes the resources of the pages that are not
+// rendered, which would otherwise be published when the page is rendered.
 func headlessPagesPublisher(s *Site, wg *sync.WaitGroup) {
 	defer wg.Done()
-	for _, p := range s.headlessPages {
-		if err := p.renderResources(); err != nil {
-			s.SendError(p.errorf(err, "failed to render page resources"))
+	for _, pages := range []pageStatePages{s.headlessPages, s.workAllPages} {
+		for _, p := range pages {
+			if !p.m.noRender() {
+				continue
+			}
+			if err := p.renderResources(); err != nil {
+				s.SendError(p.errorf(err, "failed to render page resources"))
+			}
 		}
 	}
 }
@@ -153,7 +160,7 @@ func pageRenderer(
 			results <- err
 		}
 
-		if p.paginator != nil && p.paginator.current != nil {
+		if p.paginator != nil && p.paginator.isUsed() {
 			if err := s.renderPaginator(p, layouts); err != nil {
 				results <- err
 			}
@@ -161,32 +168,92 @@ func pageRenderer(
 	}
 }
 
+// reportTargetPathConflicts logs an error for every page that would be
+// published to the same file as another page in the site, e.g. because of
+// the permalinks configuration or the same url set in front matter.
+func (s *Site) reportTargetPathConflicts() error {
+	targets := make(map[string]*pageState)
+
+	for _, p := range s.workAllPages {
+		if p.m.noRender() {
+			continue
+		}
+
+		if err := p.initPage(); err != nil {
+			return err
+		}
+
+		for _, f := range p.OutputFormats() {
+			d := p.targetPathDescriptor
+			d.Type = f.Format
+			filename := page.CreateTargetPaths(d).TargetFilename
+
+			if other, found := targets[filename]; found {
+				s.Log.ERROR.Printf("Pages %q and %q have the same target path %q", other.pathOrTitle(), p.pathOrTitle(), filename)
+				continue
+			}
+
+			targets[filename] = p
+		}
+	}
+
+	return nil
+}
+
 // renderPaginator must be run after the owning Page has been rendered.
 func (s *Site) renderPaginator(p *pageState, layouts []string) error {
 
-	paginatePath := s.Cfg.GetString("paginatePath")
-
 	d := p.targetPathDescriptor
-	f := p.s.rc.Format
-	d.Type = f
+	d.Type = p.s.rc.Format
 
-	if p.paginator.current == nil || p.paginator.current != p.paginator.current.First() {
-		panic(fmt.Sprintf("invalid paginator state for %q", p.pathOrTitle()))
+	if p.paginator.current != nil {
+		if p.paginator.current != p.paginator.current.First() {
+			panic(fmt.Sprintf("invalid paginator state for %q", p.pathOrTitle()))
+		}
+
+		if err := s.renderPagers(p, d, s.Cfg.GetString("paginatePath"), &p.paginator.current, layouts); err != nil {
+			return err
+		}
 	}
 
+	// More named paginators may be created while rendering the pagers,
+	// so look them up by index.
+	for i := 0; ; i++ {
+		n := p.paginator.namedPaginator(i)
+		if n == nil {
+			break
+		}
+
+		if err := s.renderPagers(p, d, n.paginatePath, &n.current, layouts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderPagers renders the pagers after the first below paginatePath, with
+// an alias for page 1. current is set to the pager being rendered and reset
+// to the first when done.
+func (s *Site) renderPagers(p *pageState, d page.TargetPathDescriptor, paginatePath string, current **page.Pager, layouts []string) error {
+	first := (*current).First()
+	defer func() {
+		*current = first
+	}()
+
 	// Write alias for page 1
 	d.Addends = fmt.Sprintf("/%s/%d", paginatePath, 1)
 	targetPaths := page.CreateTargetPaths(d)
 
-	if err := s.writeDestAlias(targetPaths.TargetFilename, p.Permalink(), f, nil); err != nil {
+	if err := s.writeDestAlias(targetPaths.TargetFilename, p.Permalink(), d.Type, nil); err != nil {
 		return err
 	}
 
 	// Render pages for the rest
-	for current := p.paginator.current.Next(); current != nil; current = current.Next() {
+	for pager := first.Next(); pager != nil; pager = pager.Next() {
 
-		p.paginator.current = current
-		d.Addends = fmt.Sprintf("/%s/%d", paginatePath, current.PageNumber())
+		*current = pager
+		d.Addends = fmt.Sprintf("/%s/%d", paginatePath, pager.PageNumber())
 		targetPaths := page.CreateTargetPaths(d)
 
 		if err := s.renderAndWritePage(
@@ -292,7 +359,7 @@ func (s *Site) renderRobotsTXT() error {
 func (s *Site) renderAliases() error {
 	for _, p := range s.workAllPages {
 
-		if len(p.Aliases()) == 0 {
+		if len(p.Aliases()) == 0 || p.m.noRender() {
 			continue
 		}
 
@@ -181,6 +181,9 @@ func (s *Site) assembleSections() pageStatePages {
 
 		// Regular page
 		p.parent = currentSection
+		if p.m.noList() {
+			return false
+		}
 		children = append(children, p)
 		if dates != nil {
 			dates.UpdateDateAndLastmodIfAfter(p)
@@ -210,7 +213,9 @@ func (s *Site) assembleSections() pageStatePages {
 			sect.parent = p
 		}
 
-		sect.addSectionToParent()
+		if !sect.m.noList() {
+			sect.addSectionToParent()
+		}
 	}
 
 	var (
@@ -242,3 +247,64 @@ func (s *Site) assembleSections() pageStatePages {
 	return newPages
 
 }
+
+// applyCascade applies the front matter cascade from the home page and the
+// sections to the given pages. A page gets the cascade from all its ancestors,
+// where the values set closest to the page win.
+func (s *Site) applyCascade(pages pageStatePages) error {
+	cascades := make(map[string]map[string]interface{})
+	for _, p := range s.rawAllPages {
+		if len(p.m.cascade) == 0 {
+			continue
+		}
+		switch p.Kind() {
+		case page.KindHome, page.KindSection:
+			cascades[p.SectionsPath()] = p.m.cascade
+		}
+	}
+
+	for _, p := range pages {
+		changed, err := p.m.applyCascade(p, cascadeForPage(p, cascades))
+		if err != nil {
+			return p.wrapError(err)
+		}
+		if changed {
+			// Make sure the output formats, paths etc. are
+			// recreated from the new values on rebuilds.
+			p.init.Reset()
+		}
+	}
+
+	return nil
+}
+
+// cascadeForPage merges the cascades of the given page's ancestors, keyed by
+// their sections path.
+func cascadeForPage(p *pageState, cascades map[string]map[string]interface{}) map[string]interface{} {
+	if len(cascades) == 0 || p.Kind() == page.KindHome {
+		return nil
+	}
+
+	sections := p.SectionsEntries()
+	depth := len(sections)
+	if p.IsNode() {
+		// A section is not its own ancestor.
+		depth--
+	}
+
+	var cascade map[string]interface{}
+	for i := 0; i <= depth; i++ {
+		c, found := cascades[path.Join(sections[:i]...)]
+		if !found {
+			continue
+		}
+		if cascade == nil {
+			cascade = make(map[string]interface{})
+		}
+		for k, v := range c {
+			cascade[k] = v
+		}
+	}
+
+	return cascade
+}
@@ -375,3 +375,90 @@ Next: {{ with .NextInSection }}{{ .RelPermalink }}{{ end }}|
 		"Prev: |", "Next: /blog/cool/cool1/|")
 
 }
+
+func TestPageAncestorsAndBreadcrumbs(t *testing.T) {
+
+	config := `
+baseURL = "https://example.com"
+defaultContentLanguage = "en"
+
+[languages]
+[languages.en]
+title = "Hugo Site"
+weight = 1
+[languages.nn]
+title = "Hugo Side"
+weight = 2
+`
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+
+	b.WithContent("docs/_index.md", `
+---
+title: "Docs"
+---
+`, "docs/sub/_index.md", `
+---
+title: "Sub"
+---
+`, "docs/sub/p1.md", `
+---
+title: "P1"
+tags: ["Hugo"]
+---
+`, "docs/_index.nn.md", `
+---
+title: "Dokumentasjon"
+---
+`, "docs/sub/_index.nn.md", `
+---
+title: "Under"
+---
+`, "docs/sub/p1.nn.md", `
+---
+title: "S1"
+---
+`)
+
+	breadcrumbsTemplate := `
+Ancestors: {{ range .Ancestors }}{{ .Title }}|{{ end }}
+Breadcrumbs: {{ range .Breadcrumbs }}{{ .LinkTitle }}:{{ .RelPermalink }}|{{ end }}
+{{ template "_internal/schema.html" . }}
+`
+
+	b.WithTemplates(
+		"_default/single.html", breadcrumbsTemplate,
+		"_default/list.html", breadcrumbsTemplate,
+		"_default/taxonomy.html", breadcrumbsTemplate,
+		"_default/terms.html", breadcrumbsTemplate,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/docs/sub/p1/index.html",
+		"Ancestors: Sub|Docs|Hugo Site|",
+		"Breadcrumbs: Hugo Site:/|Docs:/docs/|Sub:/docs/sub/|P1:/docs/sub/p1/|",
+		`{"@context":"https://schema.org","@type":"BreadcrumbList","itemListElement":[{"@type":"ListItem","item":"https://example.com/","name":"Hugo Site","position":1},{"@type":"ListItem","item":"https://example.com/docs/","name":"Docs","position":2}`,
+		`{"@type":"ListItem","item":"https://example.com/docs/sub/p1/","name":"P1","position":4}]}`,
+	)
+
+	b.AssertFileContent("public/nn/docs/sub/p1/index.html",
+		"Ancestors: Under|Dokumentasjon|Hugo Side|",
+		"Breadcrumbs: Hugo Side:/nn/|Dokumentasjon:/nn/docs/|Under:/nn/docs/sub/|S1:/nn/docs/sub/p1/|",
+	)
+
+	b.AssertFileContent("public/tags/hugo/index.html",
+		"Ancestors: Tags|Hugo Site|",
+		"Breadcrumbs: Hugo Site:/|Tags:/tags/|Hugo:/tags/hugo/|",
+	)
+
+	b.AssertFileContent("public/tags/index.html",
+		"Ancestors: Hugo Site|",
+		"Breadcrumbs: Hugo Site:/|Tags:/tags/|",
+	)
+
+	b.AssertFileContent("public/index.html",
+		"Ancestors: \nBreadcrumbs: Hugo Site:/|\n",
+	)
+	require.NotContains(t, b.FileContent("public/index.html"), "BreadcrumbList")
+}
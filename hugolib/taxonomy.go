@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"path"
 	"sort"
+	"strings"
 
 	"github.com/gohugoio/hugo/resources/page"
 	"github.com/gohugoio/hugo/resources/resource"
@@ -174,6 +175,18 @@ type taxonomyNodeInfo struct {
 
 	parent *taxonomyNodeInfo
 
+	// Set if the terms in this taxonomy form a hierarchy, e.g.
+	// "hardware/storage/ssd". Only set on the taxonomy node.
+	hierarchical bool
+
+	// The term above this term in a hierarchical taxonomy, e.g. "hardware"
+	// for "hardware/storage". Nil for the top level terms.
+	parentTerm *taxonomyNodeInfo
+
+	// The terms directly below this term in a hierarchical taxonomy, or the
+	// top level terms for the taxonomy node.
+	children []*taxonomyNodeInfo
+
 	// Either of Kind taxonomyTerm (parent) or taxonomy
 	owner *page.PageWrapper
 }
@@ -184,6 +197,36 @@ func (t *taxonomyNodeInfo) UpdateFromPage(p page.Page) {
 	t.dates.UpdateDateAndLastmodIfAfter(p)
 }
 
+// title returns the title of the term, which is the last part of the term
+// in a hierarchical taxonomy.
+func (t *taxonomyNodeInfo) title() string {
+	if t.parent != nil && t.parent.hierarchical {
+		return path.Base(t.term)
+	}
+	return t.term
+}
+
+func (t *taxonomyNodeInfo) addChild(child *taxonomyNodeInfo) {
+	for _, c := range t.children {
+		if c == child {
+			return
+		}
+	}
+	t.children = append(t.children, child)
+}
+
+// childPages returns the pages of the terms directly below this node.
+func (t *taxonomyNodeInfo) childPages() page.Pages {
+	var pages page.Pages
+	for _, c := range t.children {
+		if c.owner.Page != nil {
+			pages = append(pages, c.owner.Page)
+		}
+	}
+	page.SortByDefault(pages)
+	return pages
+}
+
 func (t *taxonomyNodeInfo) TransferValues(p *pageState) {
 	t.owner.Page = p
 	if p.Lastmod().IsZero() && p.Date().IsZero() {
@@ -191,6 +234,25 @@ func (t *taxonomyNodeInfo) TransferValues(p *pageState) {
 	}
 }
 
+// hierarchicalTerms returns the given term and its ancestors in a
+// hierarchical taxonomy, top level first, e.g. "a", "a/b" and "a/b/c" for
+// "a/b/c".
+func hierarchicalTerms(term string) []string {
+	var parts []string
+	for _, part := range strings.Split(term, "/") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	terms := make([]string, len(parts))
+	for i := range parts {
+		terms[i] = strings.Join(parts[:i+1], "/")
+	}
+
+	return terms
+}
+
 // Maps either plural or plural/term to a taxonomy node.
 // TODO(bep) consolidate somehow with s.Taxonomies
 type taxonomyNodeInfos struct {
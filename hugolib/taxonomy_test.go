@@ -326,3 +326,102 @@ Content.
 	b.AssertFileContent("public/tags/index.html", `<li><a href="http://example.com/tags/rocks-i-say/">Rocks I say!</a> 10</li>`)
 
 }
+
+func TestTaxonomiesHierarchical(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", `
+baseURL = "https://example.com"
+hierarchicalTaxonomies = ["categories"]
+
+[taxonomies]
+category = "categories"
+tag = "tags"
+`)
+
+	b.WithContent(
+		"p1.md", `---
+title: P1
+categories: ["Hardware/Storage/SSD"]
+tags: ["a/b"]
+---
+`,
+		"p2.md", `---
+title: P2
+categories: ["Hardware/Storage", "Hardware/Storage/HDD"]
+---
+`,
+		"p3.md", `---
+title: P3
+categories: ["Hardware/Network"]
+---
+`)
+
+	b.WithTemplatesAdded(
+		"_default/taxonomy.html", `{{ .Title }}|Pages:{{ range .Pages }}{{ .Title }};{{ end }}|Children:{{ range .Children }}{{ .Title }};{{ end }}|Crumbs:{{ template "crumbs" .Parent }}|{{ .RelPermalink }}
+{{ define "crumbs" }}{{ with . }}{{ template "crumbs" .Parent }}{{ .Title }}>{{ end }}{{ end }}`,
+		"_default/terms.html", `{{ .Title }}|Children:{{ range .Children }}{{ .Title }};{{ end }}`,
+	)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/categories/hardware/index.html", "Hardware|Pages:P1;P2;P3;|Children:Network;Storage;|Crumbs:Categories>|/categories/hardware/")
+	b.AssertFileContent("public/categories/hardware/storage/index.html", "Storage|Pages:P1;P2;|Children:HDD;SSD;|Crumbs:Categories>Hardware>|/categories/hardware/storage/")
+	b.AssertFileContent("public/categories/hardware/storage/ssd/index.html", "SSD|Pages:P1;|Children:|Crumbs:Categories>Hardware>Storage>|/categories/hardware/storage/ssd/")
+	b.AssertFileContent("public/categories/index.html", "Categories|Children:Hardware;")
+
+	// Tags are not hierarchical.
+	b.AssertFileContent("public/tags/a/b/index.html", "a/b|Pages:P1;|Children:|Crumbs:|")
+	assert.False(b.CheckExists("public/tags/a/index.html"))
+
+	categories := b.H.Sites[0].Taxonomies["categories"]
+	assert.Len(categories["hardware"], 3)
+	assert.Len(categories["hardware/storage"], 2)
+}
+
+func TestTaxonomyPositionIn(t *testing.T) {
+	t.Parallel()
+
+	config := `
+baseURL = "https://example.com"
+
+[taxonomies]
+tag = "tags"
+series = "series"
+`
+
+	b := newTestSitesBuilder(t).WithConfigFile("toml", config)
+
+	pageTemplate := `---
+title: %q
+series: %s
+series_weight: %d
+---
+`
+
+	b.WithContent(
+		"tutorial/part-a.md", fmt.Sprintf(pageTemplate, "Part A", `["Go Generics"]`, 3),
+		"tutorial/part-b.md", fmt.Sprintf(pageTemplate, "Part B", `["Go Generics"]`, 1),
+		"tutorial/part-c.md", fmt.Sprintf(pageTemplate, "Part C", `["Go Generics", "Other"]`, 2),
+		"tutorial/no-series.md", `---
+title: "No Series"
+---
+`)
+
+	b.WithTemplates("_default/single.html", `
+{{- with .PositionIn "series" "go-generics" -}}
+Part {{ .Number }} of {{ .Total }}|Prev: {{ with .Prev }}{{ .Title }}{{ end }}|Next: {{ with .Next }}{{ .Title }}{{ end }}|First: {{ .IsFirst }}|Last: {{ .IsLast }}
+{{- else -}}
+Not in series
+{{- end -}}
+`)
+
+	b.Build(BuildCfg{})
+
+	b.AssertFileContent("public/tutorial/part-b/index.html", "Part 1 of 3|Prev: |Next: Part C|First: true|Last: false")
+	b.AssertFileContent("public/tutorial/part-c/index.html", "Part 2 of 3|Prev: Part B|Next: Part A|First: false|Last: false")
+	b.AssertFileContent("public/tutorial/part-a/index.html", "Part 3 of 3|Prev: Part C|Next: |First: false|Last: true")
+	b.AssertFileContent("public/tutorial/no-series/index.html", "Not in series")
+}
@@ -41,6 +41,7 @@ func TestDefaultTypes(t *testing.T) {
 		{XMLType, "application", "xml", "xml", "application/xml", "application/xml"},
 		{TOMLType, "application", "toml", "toml", "application/toml", "application/toml"},
 		{YAMLType, "application", "yaml", "yaml", "application/yaml", "application/yaml"},
+		{WEBPType, "image", "webp", "webp", "image/webp", "image/webp"},
 	} {
 		require.Equal(t, test.expectedMainType, test.tp.MainType)
 		require.Equal(t, test.expectedSubType, test.tp.SubType)
@@ -52,7 +53,7 @@ func TestDefaultTypes(t *testing.T) {
 
 	}
 
-	require.Equal(t, 17, len(DefaultTypes))
+	require.Equal(t, 18, len(DefaultTypes))
 
 }
 
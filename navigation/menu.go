@@ -14,6 +14,7 @@
 package navigation
 
 import (
+	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/common/types"
 
 	"html/template"
@@ -23,10 +24,11 @@ import (
 	"github.com/spf13/cast"
 )
 
-// MenuEntry represents a menu item defined in either Page front matter
-// or in the site config.
+// MenuEntry represents a menu item defined in either Page front matter,
+// the site config or a data file in data/menus.
 type MenuEntry struct {
 	ConfiguredURL string // The URL value from front matter / config.
+	PageRef       string // A page reference from config / data, resolved to Page.
 	Page          Page
 	Name          string
 	Menu          string
@@ -37,6 +39,10 @@ type MenuEntry struct {
 	Weight        int
 	Parent        string
 	Children      Menu
+
+	// Params is a free-form map of user defined values, e.g. an icon name or
+	// a description. Keys are lower-cased.
+	Params map[string]interface{}
 }
 
 func (m *MenuEntry) URL() string {
@@ -56,6 +62,7 @@ type Page interface {
 	LinkTitle() string
 	RelPermalink() string
 	Section() string
+	SectionsPath() string
 	Weight() int
 	IsPage() bool
 	Params() map[string]interface{}
@@ -125,6 +132,14 @@ func (m *MenuEntry) MarshallMap(ime map[string]interface{}) {
 			m.Identifier = cast.ToString(v)
 		case "parent":
 			m.Parent = cast.ToString(v)
+		case "pageref":
+			m.PageRef = cast.ToString(v)
+		case "params":
+			params, err := cast.ToStringMapE(v)
+			if err == nil {
+				maps.ToLower(params)
+				m.Params = params
+			}
 		}
 	}
 }
@@ -14,6 +14,8 @@
 package navigation
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 )
@@ -119,11 +121,17 @@ type pageMenus struct {
 func (pm *pageMenus) HasMenuCurrent(menuID string, me *MenuEntry) bool {
 
 	// page is labeled as "shadow-member" of the menu with the same identifier as the section
+	// or, for nested sections, any of its parent sections.
 	if pm.setionPagesMenu != "" {
 		section := pm.p.Section()
 
-		if section != "" && pm.setionPagesMenu == menuID && section == me.Identifier {
-			return true
+		if section != "" && pm.setionPagesMenu == menuID {
+			if section == me.Identifier {
+				return true
+			}
+			if me.Identifier != "" && strings.HasPrefix(pm.p.SectionsPath()+"/", me.Identifier+"/") {
+				return true
+			}
 		}
 	}
 
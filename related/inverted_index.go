@@ -70,6 +70,10 @@ type Config struct {
 	// May get better results, but at a slight performance cost.
 	ToLower bool
 
+	// The language code used to remove stop words and to stem the words in
+	// the TF-IDF indices, e.g. "en". Hugo sets this to the site's language.
+	Language string
+
 	Indices IndexConfigs
 }
 
@@ -84,11 +88,25 @@ func (c *Config) Add(index IndexConfig) {
 // IndexConfigs holds a set of index configurations.
 type IndexConfigs []IndexConfig
 
+// The index types.
+const (
+	// TypeBasic matches the documents on their exact keywords, e.g. tags.
+	// This is the default.
+	TypeBasic = "basic"
+
+	// TypeTFIDF matches the documents on the similarity of their text, e.g.
+	// the content, weighted by TF-IDF.
+	TypeTFIDF = "tfidf"
+)
+
 // IndexConfig configures an index.
 type IndexConfig struct {
 	// The index name. This directly maps to a field or Param name.
 	Name string
 
+	// The index type, either "basic" (default) or "tfidf".
+	Type string
+
 	// Contextual pattern used to convert the Param value into a string.
 	// Currently only used for dates. Can be used to, say, bump posts in the same
 	// time frame when searching for related documents.
@@ -123,6 +141,9 @@ type InvertedIndex struct {
 	cfg   Config
 	index map[string]map[Keyword][]Document
 
+	// The term vectors for the indices of type TypeTFIDF.
+	text map[string]*textIndex
+
 	minWeight int
 	maxWeight int
 }
@@ -140,9 +161,19 @@ func (idx *InvertedIndex) getIndexCfg(name string) (IndexConfig, bool) {
 // NewInvertedIndex creates a new InvertedIndex.
 // Documents to index must be added in Add.
 func NewInvertedIndex(cfg Config) *InvertedIndex {
-	idx := &InvertedIndex{index: make(map[string]map[Keyword][]Document), cfg: cfg}
+	return NewInvertedIndexWithTermCache(cfg, nil)
+}
+
+// NewInvertedIndexWithTermCache creates a new InvertedIndex that gets the
+// terms for the TF-IDF indices from the given cache, if set.
+// Documents to index must be added in Add.
+func NewInvertedIndexWithTermCache(cfg Config, cache *TermCache) *InvertedIndex {
+	idx := &InvertedIndex{index: make(map[string]map[Keyword][]Document), text: make(map[string]*textIndex), cfg: cfg}
 	for _, conf := range cfg.Indices {
 		idx.index[conf.Name] = make(map[Keyword][]Document)
+		if conf.Type == TypeTFIDF {
+			idx.text[conf.Name] = newTextIndex(cfg.Language, cache)
+		}
 		if conf.Weight < idx.minWeight {
 			// By default, the weight scale starts at 0, but we allow
 			// negative weights.
@@ -173,6 +204,11 @@ func (idx *InvertedIndex) Add(docs ...Document) error {
 				continue
 			}
 
+			if ti, found := idx.text[config.Name]; found {
+				ti.add(doc, words)
+				continue
+			}
+
 			for _, keyword := range words {
 				setm[keyword] = append(setm[keyword], doc)
 			}
@@ -257,7 +293,7 @@ func (idx *InvertedIndex) SearchDoc(doc Document, indices ...string) ([]Document
 
 	}
 
-	return idx.searchDate(doc.PublishDate(), q...)
+	return idx.searchDate(doc, doc.PublishDate(), q...)
 }
 
 // ToKeywords returns a Keyword slice of the given input.
@@ -328,13 +364,24 @@ func (idx *InvertedIndex) SearchKeyValues(args ...types.KeyValues) ([]Document,
 }
 
 func (idx *InvertedIndex) search(query ...queryElement) ([]Document, error) {
-	return idx.searchDate(zeroDate, query...)
+	return idx.searchDate(nil, zeroDate, query...)
 }
 
-func (idx *InvertedIndex) searchDate(upperDate time.Time, query ...queryElement) ([]Document, error) {
+// searchDate searches the indices with the given query. doc is the document
+// searched for related documents, if any.
+func (idx *InvertedIndex) searchDate(doc Document, upperDate time.Time, query ...queryElement) ([]Document, error) {
 	matchm := make(map[Document]*rank, 200)
 	applyDateFilter := !idx.cfg.IncludeNewer && !upperDate.IsZero()
 
+	addWeight := func(doc Document, weight int) {
+		r, found := matchm[doc]
+		if !found {
+			matchm[doc] = newRank(doc, weight)
+		} else {
+			r.addWeight(weight)
+		}
+	}
+
 	for _, el := range query {
 		setm, found := idx.index[el.Index]
 		if !found {
@@ -346,6 +393,28 @@ func (idx *InvertedIndex) searchDate(upperDate time.Time, query ...queryElement)
 			return []Document{}, fmt.Errorf("index config for %q not found", el.Index)
 		}
 
+		if ti, found := idx.text[el.Index]; found {
+			sims := ti.similarities(el.Keywords)
+
+			// Scale the similarities so the most similar document, other
+			// than the document itself, gets the full index weight.
+			var max float64
+			for d, sim := range sims {
+				if d != doc && sim > max {
+					max = sim
+				}
+			}
+
+			for d, sim := range sims {
+				if applyDateFilter && d.PublishDate().After(upperDate) {
+					continue
+				}
+				addWeight(d, int(math.Round(float64(config.Weight)*math.Min(sim/max, 1))))
+			}
+
+			continue
+		}
+
 		for _, kw := range el.Keywords {
 			if docs, found := setm[kw]; found {
 				for _, doc := range docs {
@@ -355,12 +424,7 @@ func (idx *InvertedIndex) searchDate(upperDate time.Time, query ...queryElement)
 							continue
 						}
 					}
-					r, found := matchm[doc]
-					if !found {
-						matchm[doc] = newRank(doc, config.Weight)
-					} else {
-						r.addWeight(config.Weight)
-					}
+					addWeight(doc, config.Weight)
 				}
 			}
 		}
@@ -426,10 +490,19 @@ func DecodeConfig(in interface{}) (Config, error) {
 		return Config{}, errors.New("related threshold must be between 0 and 100")
 	}
 
-	if c.ToLower {
-		for i := range c.Indices {
+	for i, index := range c.Indices {
+		if c.ToLower {
 			c.Indices[i].ToLower = true
 		}
+
+		switch strings.ToLower(index.Type) {
+		case "", TypeBasic:
+			c.Indices[i].Type = TypeBasic
+		case TypeTFIDF:
+			c.Indices[i].Type = TypeTFIDF
+		default:
+			return Config{}, fmt.Errorf("invalid type %q for related index %q", index.Type, index.Name)
+		}
 	}
 
 	return c, nil
@@ -0,0 +1,58 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package related
+
+import "strings"
+
+// stopWords maps a language code to the words left out of the TF-IDF indices.
+var stopWords = map[string]map[string]bool{
+	"en": toSet(`a about above after again against all am an and any are as at be because been before being below
+between both but by can could did do does doing down during each few for from further had has have having he her
+here hers herself him himself his how i if in into is it its itself just me more most my myself no nor not now of
+off on once only or other our ours ourselves out over own same she should so some such than that the their theirs
+them themselves then there these they this those through to too under until up very was we were what when where
+which while who whom why will with would you your yours yourself yourselves`),
+
+	"de": toSet(`aber alle allem allen aller alles als also am an ander andere anderem anderen anderer anderes auch auf
+aus bei bin bis bist da damit dann das dass dein deine dem den der des dich die dies diese diesem diesen dieser
+dieses dir doch dort du durch ein eine einem einen einer eines er es euch euer für hab habe haben hat hatte hier
+hin ich ihm ihn ihr ihre im in ist ja jede jedem jeden jeder jedes kein keine man mein meine mich mir mit nach
+nicht noch nun nur ob oder ohne sehr sein seine sich sie sind so über um und uns unser unter vom von vor war waren
+was weil wenn wer wie wir wird wo zu zum zur`),
+
+	"es": toSet(`al algo algunas algunos ante antes como con contra cual cuando de del desde donde durante el ella
+ellas ellos en entre era es esa esas ese eso esos esta estas este esto estos fue ha hay la las le les lo los más me
+mi mucho muy nada ni no nos nosotros o os otra otro para pero poco por porque que quien se sea ser si sin sobre
+son su sus también te tiene todo todos tu tus un una uno unos vosotros y ya yo`),
+
+	"fr": toSet(`ai au aux avec ce ces cette dans de des du elle en et eux il ils je la le les leur lui ma mais me
+même mes moi mon ne nos notre nous on ou où par pas pour qu que qui sa se ses son sont sur ta te tes toi ton tu un
+une vos votre vous est était été être avoir ont fait comme plus tout tous très`),
+
+	"nb": toSet(norwegianStopWords),
+	"nn": toSet(norwegianStopWords),
+	"no": toSet(norwegianStopWords),
+}
+
+const norwegianStopWords = `at av da de dei deg den denne der det dette di du eg ein eit eller en er et etter for
+fra frå før ha han ho hun hva hvor i ikkje ikke inn jeg kan kva kvar med meg men mot må ned no noe noko nå og om
+opp oss på seg selv sin si sitt skal som så til ut var vi vil være vere å`
+
+func toSet(words string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		m[w] = true
+	}
+	return m
+}
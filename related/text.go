@@ -0,0 +1,309 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package related
+
+import (
+	"crypto/md5"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// textIndex holds the term vectors of the documents in a TF-IDF index.
+type textIndex struct {
+	analyzer textAnalyzer
+	cache    *TermCache
+
+	// The term frequencies per document.
+	docs map[Document]map[string]int
+
+	// The number of documents each term is found in.
+	df map[string]int
+
+	// The weighted term vectors, their lengths and the documents per term,
+	// calculated from the above when the index is searched.
+	mu       sync.Mutex
+	dirty    bool
+	vectors  map[Document]map[string]float64
+	norms    map[Document]float64
+	postings map[string][]Document
+}
+
+func newTextIndex(lang string, cache *TermCache) *textIndex {
+	return &textIndex{
+		analyzer: newTextAnalyzer(lang),
+		cache:    cache,
+		docs:     make(map[Document]map[string]int),
+		df:       make(map[string]int),
+	}
+}
+
+// add adds the document with the given words to the index.
+func (t *textIndex) add(doc Document, words []Keyword) {
+	tf := t.termFrequencies(words)
+	if len(tf) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if old, found := t.docs[doc]; found {
+		for term := range old {
+			t.df[term]--
+		}
+	}
+
+	for term := range tf {
+		t.df[term]++
+	}
+
+	t.docs[doc] = tf
+	t.dirty = true
+}
+
+// termFrequencies returns the term frequencies for the given words, from
+// the cache if set.
+func (t *textIndex) termFrequencies(words []Keyword) map[string]int {
+	if t.cache == nil {
+		return t.analyzer.termFrequencies(words)
+	}
+	return t.cache.getOrCreate(t.analyzer, words)
+}
+
+// idf returns the smoothed inverse document frequency of the given term.
+// This assumes that a lock has been acquired.
+func (t *textIndex) idf(term string) float64 {
+	return math.Log(float64(1+len(t.docs))/float64(1+t.df[term])) + 1
+}
+
+// weigh returns the TF-IDF weighted vector for the given term frequencies
+// and its length.
+// This assumes that a lock has been acquired.
+func (t *textIndex) weigh(tf map[string]int) (map[string]float64, float64) {
+	v := make(map[string]float64, len(tf))
+	var sum float64
+	for term, n := range tf {
+		if t.df[term] == 0 {
+			continue
+		}
+		w := (1 + math.Log(float64(n))) * t.idf(term)
+		v[term] = w
+		sum += w * w
+	}
+	return v, math.Sqrt(sum)
+}
+
+func (t *textIndex) init() {
+	if !t.dirty && t.vectors != nil {
+		return
+	}
+
+	t.vectors = make(map[Document]map[string]float64, len(t.docs))
+	t.norms = make(map[Document]float64, len(t.docs))
+	t.postings = make(map[string][]Document, len(t.df))
+
+	for doc, tf := range t.docs {
+		t.vectors[doc], t.norms[doc] = t.weigh(tf)
+		for term := range tf {
+			t.postings[term] = append(t.postings[term], doc)
+		}
+	}
+
+	t.dirty = false
+}
+
+// similarities returns the cosine similarity between the given words and
+// the documents in the index with any terms in common.
+func (t *textIndex) similarities(words []Keyword) map[Document]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.init()
+
+	q, qnorm := t.weigh(t.termFrequencies(words))
+	if qnorm == 0 {
+		return nil
+	}
+
+	sims := make(map[Document]float64)
+
+	for term, w := range q {
+		for _, doc := range t.postings[term] {
+			sims[doc] += w * t.vectors[doc][term]
+		}
+	}
+
+	for doc, dot := range sims {
+		sims[doc] = dot / (qnorm * t.norms[doc])
+	}
+
+	return sims
+}
+
+// TermCache caches the terms found in the documents' text, keyed by a hash of
+// the text, so the text of unchanged documents is not analyzed again when
+// the indices are rebuilt, e.g. in server mode.
+type TermCache struct {
+	mu sync.Mutex
+
+	// The terms used since the last call to Next and before that.
+	cur  map[[md5.Size]byte]map[string]int
+	prev map[[md5.Size]byte]map[string]int
+}
+
+// NewTermCache creates a new TermCache.
+func NewTermCache() *TermCache {
+	return &TermCache{cur: make(map[[md5.Size]byte]map[string]int)}
+}
+
+// Next returns the cache to use for the next build. It keeps the terms used
+// in the current and the previous build only, to drop the terms of
+// documents that are changed or removed.
+func (c *TermCache) Next() *TermCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.cur) == 0 {
+		// The indices were not built, keep what we have.
+		return &TermCache{cur: make(map[[md5.Size]byte]map[string]int), prev: c.prev}
+	}
+
+	return &TermCache{cur: make(map[[md5.Size]byte]map[string]int), prev: c.cur}
+}
+
+// The returned map must not be modified.
+func (c *TermCache) getOrCreate(a textAnalyzer, words []Keyword) map[string]int {
+	h := md5.New()
+	h.Write([]byte(a.lang))
+	for _, word := range words {
+		h.Write([]byte{0})
+		h.Write([]byte(word.String()))
+	}
+	var key [md5.Size]byte
+	copy(key[:], h.Sum(nil))
+
+	c.mu.Lock()
+	tf, found := c.cur[key]
+	if !found {
+		tf, found = c.prev[key]
+		if found {
+			c.cur[key] = tf
+		}
+	}
+	c.mu.Unlock()
+
+	if found {
+		return tf
+	}
+
+	tf = a.termFrequencies(words)
+
+	c.mu.Lock()
+	c.cur[key] = tf
+	c.mu.Unlock()
+
+	return tf
+}
+
+// textAnalyzer turns words into the terms to index: lower case, without
+// punctuation and stop words, and stemmed, if supported for the language.
+type textAnalyzer struct {
+	lang      string
+	stopWords map[string]bool
+	stem      func(s string) string
+}
+
+func newTextAnalyzer(lang string) textAnalyzer {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "-_"); i != -1 {
+		lang = lang[:i]
+	}
+
+	a := textAnalyzer{lang: lang, stopWords: stopWords[lang], stem: stemmers[lang]}
+	if a.stem == nil {
+		a.stem = func(s string) string { return s }
+	}
+
+	return a
+}
+
+func (a textAnalyzer) termFrequencies(words []Keyword) map[string]int {
+	tf := make(map[string]int)
+
+	for _, word := range words {
+		tokens := strings.FieldsFunc(strings.ToLower(word.String()), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+
+		for _, token := range tokens {
+			if utf8.RuneCountInString(token) < 2 || a.stopWords[token] {
+				continue
+			}
+			tf[a.stem(token)]++
+		}
+	}
+
+	return tf
+}
+
+// stemmers maps a language code to its stemmer.
+var stemmers = map[string]func(s string) string{
+	"en": stemEnglish,
+}
+
+// stemEnglish is a light English stemmer that removes the most common
+// inflectional suffixes, e.g. "indexes", "indexed" and "indexing" all
+// become "index".
+func stemEnglish(s string) string {
+	if len(s) <= 3 {
+		return s
+	}
+
+	switch {
+	case strings.HasSuffix(s, "sses"):
+		s = s[:len(s)-2]
+	case strings.HasSuffix(s, "ies") && len(s) > 4:
+		s = s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "xes") || strings.HasSuffix(s, "ches") || strings.HasSuffix(s, "shes"):
+		s = s[:len(s)-2]
+	case strings.HasSuffix(s, "s") && !strings.HasSuffix(s, "ss") && !strings.HasSuffix(s, "us") && !strings.HasSuffix(s, "is"):
+		s = s[:len(s)-1]
+	}
+
+	for _, suffix := range []string{"ingly", "edly", "ing", "ed", "ly"} {
+		if !strings.HasSuffix(s, suffix) {
+			continue
+		}
+		stem := s[:len(s)-len(suffix)]
+		if len(stem) < 3 || !strings.ContainsAny(stem, "aeiouy") {
+			break
+		}
+		// Undouble the last consonant, e.g. "running" => "run".
+		if n := len(stem); stem[n-1] == stem[n-2] && !strings.ContainsRune("aeiouylsz", rune(stem[n-1])) {
+			stem = stem[:n-1]
+		}
+		s = stem
+		break
+	}
+
+	// Remove the final "e", e.g. "create" and "created" => "creat".
+	if len(s) > 4 && strings.HasSuffix(s, "e") {
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package related
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStemEnglish(t *testing.T) {
+	assert := require.New(t)
+
+	for _, test := range []struct {
+		in     string
+		expect string
+	}{
+		{"index", "index"},
+		{"indexes", "index"},
+		{"indexed", "index"},
+		{"indexing", "index"},
+		{"running", "run"},
+		{"stories", "story"},
+		{"create", "creat"},
+		{"created", "creat"},
+		{"class", "class"},
+		{"status", "status"},
+		{"go", "go"},
+	} {
+		assert.Equal(test.expect, stemEnglish(test.in), test.in)
+	}
+}
+
+func TestTextAnalyzer(t *testing.T) {
+	assert := require.New(t)
+
+	en := newTextAnalyzer("en-US")
+	assert.Equal(map[string]int{"hugo": 2, "render": 2, "page": 1}, en.termFrequencies(StringsToKeywords(strings.Fields("Hugo renders the pages, and Hugo is rendering!")...)))
+
+	nn := newTextAnalyzer("nn")
+	assert.Equal(map[string]int{"hugo": 1, "lagar": 1, "sider": 1}, nn.termFrequencies(StringsToKeywords(strings.Fields("Hugo lagar sider og ikkje")...)))
+}
+
+func TestSearchTFIDF(t *testing.T) {
+	assert := require.New(t)
+
+	config := Config{
+		Threshold: 50,
+		Language:  "en",
+		Indices: IndexConfigs{
+			IndexConfig{Name: "content", Type: TypeTFIDF, Weight: 100},
+			IndexConfig{Name: "tags", Weight: 50},
+		},
+	}
+
+	idx := NewInvertedIndex(config)
+
+	text := func(s string) []string { return strings.Fields(s) }
+
+	docs := []*testDoc{
+		newTestDoc("content", text("Hugo is a static site generator written in Go")...),
+		newTestDoc("content", text("Static site generators render the pages of a site")...),
+		newTestDoc("content", text("Baking bread with sourdough")...),
+		newTestDoc("content", text("Go is a programming language, Hugo is written in it")...).addKeywords("tags", "bread"),
+	}
+
+	for _, d := range docs {
+		assert.NoError(idx.Add(d))
+	}
+
+	m, err := idx.SearchDoc(newTestDoc("content", text("Generating static sites with Hugo")...))
+	assert.NoError(err)
+	assert.Len(m, 2)
+	assert.Equal(docs[0], m[0])
+	assert.Equal(docs[1], m[1])
+
+	// The text similarity is weighted together with the keywords.
+	m, err = idx.SearchDoc(newTestDoc("content", text("Sourdough bread")...).addKeywords("tags", "bread"))
+	assert.NoError(err)
+	assert.Len(m, 2)
+	assert.Equal(docs[2], m[0])
+	assert.Equal(docs[3], m[1])
+
+	_, err = DecodeConfig(map[string]interface{}{"indices": []map[string]interface{}{{"name": "content", "type": "TFIDF"}}})
+	assert.NoError(err)
+
+	_, err = DecodeConfig(map[string]interface{}{"indices": []map[string]interface{}{{"name": "content", "type": "bm42"}}})
+	assert.Error(err)
+}
+
+func TestTermCache(t *testing.T) {
+	assert := require.New(t)
+
+	a := newTextAnalyzer("en")
+	hugo := StringsToKeywords(strings.Fields("Hugo renders pages")...)
+	bread := StringsToKeywords(strings.Fields("Baking bread")...)
+
+	c := NewTermCache()
+	assert.Equal(map[string]int{"hugo": 1, "render": 1, "page": 1}, c.getOrCreate(a, hugo))
+	c.getOrCreate(a, bread)
+	c.getOrCreate(a, hugo)
+	assert.Len(c.cur, 2)
+
+	// Only the terms used in the next build are kept in the one after that.
+	c = c.Next()
+	assert.Len(c.prev, 2)
+	c.getOrCreate(a, hugo)
+	assert.Len(c.cur, 1)
+
+	c = c.Next()
+	assert.Len(c.prev, 1)
+
+	// A build without indices keeps the cache as is.
+	c = c.Next()
+	assert.Len(c.prev, 1)
+
+	idx := NewInvertedIndexWithTermCache(Config{Indices: IndexConfigs{IndexConfig{Name: "content", Type: TypeTFIDF, Weight: 100}}}, c)
+	assert.NoError(idx.Add(newTestDoc("content", "Hugo", "renders", "pages")))
+	assert.Len(c.cur, 1)
+}
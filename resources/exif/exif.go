@@ -0,0 +1,265 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exif decodes the EXIF and IPTC metadata in images.
+package exif
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	_exif "github.com/rwcarlsen/goexif/exif"
+	"github.com/rwcarlsen/goexif/tiff"
+)
+
+const exifTimeLayout = "2006:01:02 15:04:05"
+
+// Exif holds the metadata of an image.
+type Exif struct {
+	// The capture date of the image, if set.
+	Date time.Time
+
+	// The GPS coordinates of the image, if set.
+	Lat  float64
+	Long float64
+
+	// The EXIF and IPTC tags, e.g. FNumber, LensModel or Keywords. Note that
+	// all numeric values are float64.
+	Tags Tags
+}
+
+// Tags holds the decoded tags, keyed by their names.
+type Tags map[string]interface{}
+
+// Decoder decodes image metadata.
+type Decoder struct {
+	includeFieldsRe *regexp.Regexp
+	excludeFieldsRe *regexp.Regexp
+	noDate          bool
+	noLatLong       bool
+}
+
+// IncludeFields sets a regular expression matching the tags to include.
+// It is case insensitive if the expression does not start with a flag group.
+func IncludeFields(expression string) func(*Decoder) error {
+	return func(d *Decoder) error {
+		re, err := compileRegexp(expression)
+		if err != nil {
+			return err
+		}
+		d.includeFieldsRe = re
+		return nil
+	}
+}
+
+// ExcludeFields sets a regular expression matching the tags to exclude.
+// It is case insensitive if the expression does not start with a flag group.
+func ExcludeFields(expression string) func(*Decoder) error {
+	return func(d *Decoder) error {
+		re, err := compileRegexp(expression)
+		if err != nil {
+			return err
+		}
+		d.excludeFieldsRe = re
+		return nil
+	}
+}
+
+// WithDateDisabled disables the decoding of the Date field.
+func WithDateDisabled(disabled bool) func(*Decoder) error {
+	return func(d *Decoder) error {
+		d.noDate = disabled
+		return nil
+	}
+}
+
+// WithLatLongDisabled disables the decoding of the Lat and Long fields.
+func WithLatLongDisabled(disabled bool) func(*Decoder) error {
+	return func(d *Decoder) error {
+		d.noLatLong = disabled
+		return nil
+	}
+}
+
+func compileRegexp(expression string) (*regexp.Regexp, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(expression, "(") {
+		// Make it case insensitive
+		expression = "(?i)" + expression
+	}
+
+	return regexp.Compile(expression)
+}
+
+// NewDecoder creates a new Decoder with the given options.
+func NewDecoder(options ...func(*Decoder) error) (*Decoder, error) {
+	d := &Decoder{}
+	for _, opt := range options {
+		if err := opt(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// Decode decodes the EXIF and, for JPEG images, the IPTC metadata in r. It
+// returns nil if the image has no metadata. Invalid EXIF data is ignored.
+func (d *Decoder) Decode(r io.ReadSeeker) (ex *Exif, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to decode EXIF: %v", r)
+		}
+	}()
+
+	tags := make(Tags)
+
+	var (
+		tm        time.Time
+		lat, long float64
+	)
+
+	x, err := _exif.Decode(r)
+	if err != nil && _exif.IsCriticalError(err) {
+		// No or invalid EXIF data. The image may still have IPTC data.
+		x = nil
+	}
+
+	if x != nil {
+		if !d.noDate {
+			tm, _ = x.DateTime()
+		}
+
+		if !d.noLatLong {
+			lat, long, _ = x.LatLong()
+		}
+
+		if err := x.Walk(&exifWalker{d: d, tags: tags}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := r.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	for name, v := range decodeIPTC(r) {
+		if d.include(name) {
+			tags[name] = v
+		}
+	}
+
+	if x == nil && len(tags) == 0 {
+		return nil, nil
+	}
+
+	return &Exif{Date: tm, Lat: lat, Long: long, Tags: tags}, nil
+}
+
+// Orientation returns the EXIF orientation of the image in r, a value from
+// 1 to 8. It returns 1, i.e. no transformation needed, if not set.
+func Orientation(r io.Reader) int {
+	x, err := _exif.Decode(r)
+	if x == nil || (err != nil && _exif.IsCriticalError(err)) {
+		return 1
+	}
+	tag, err := x.Get(_exif.Orientation)
+	if err != nil {
+		return 1
+	}
+	v, err := tag.Int(0)
+	if err != nil || v < 1 || v > 8 {
+		return 1
+	}
+	return v
+}
+
+func (d *Decoder) include(name string) bool {
+	if d.excludeFieldsRe != nil && d.excludeFieldsRe.MatchString(name) {
+		return false
+	}
+	if d.includeFieldsRe != nil && !d.includeFieldsRe.MatchString(name) {
+		return false
+	}
+	return true
+}
+
+type exifWalker struct {
+	d    *Decoder
+	tags Tags
+}
+
+func (e *exifWalker) Walk(f _exif.FieldName, tag *tiff.Tag) error {
+	name := string(f)
+	if !e.d.include(name) {
+		return nil
+	}
+	e.tags[name] = decodeTag(tag)
+	return nil
+}
+
+func decodeTag(t *tiff.Tag) interface{} {
+	switch t.Format() {
+	case tiff.StringVal, tiff.UndefVal:
+		return nullString(t.Val)
+	case tiff.OtherVal:
+		return "unknown"
+	}
+
+	var rv []interface{}
+
+	for i := 0; i < int(t.Count); i++ {
+		switch t.Format() {
+		case tiff.RatVal:
+			n, d, _ := t.Rat2(i)
+			if d == 0 {
+				rv = append(rv, 0.0)
+				continue
+			}
+			f, _ := big.NewRat(n, d).Float64()
+			rv = append(rv, f)
+		case tiff.FloatVal:
+			v, _ := t.Float(i)
+			rv = append(rv, v)
+		case tiff.IntVal:
+			v, _ := t.Int(i)
+			rv = append(rv, float64(v))
+		}
+	}
+
+	if len(rv) == 1 {
+		return rv[0]
+	}
+
+	return rv
+}
+
+func nullString(in []byte) string {
+	var rv strings.Builder
+	for _, b := range in {
+		if b == 0 {
+			break
+		}
+		if b >= 32 && b < 127 {
+			rv.WriteByte(b)
+		}
+	}
+	return strings.TrimSpace(rv.String())
+}
@@ -0,0 +1,97 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExif(t *testing.T) {
+	assert := require.New(t)
+
+	f, err := os.Open(filepath.FromSlash("../testdata/sunset.jpg"))
+	assert.NoError(err)
+	defer f.Close()
+
+	d, err := NewDecoder()
+	assert.NoError(err)
+	x, err := d.Decode(f)
+	assert.NoError(err)
+	assert.NotNil(x)
+
+	assert.Equal("2017-10-27 08:38:52", x.Date.Format("2006-01-02 15:04:05"))
+	assert.InDelta(36.59744, x.Lat, 0.00001)
+	assert.InDelta(-4.50846, x.Long, 0.00001)
+
+	// EXIF
+	assert.Equal("PENTAX K-3 II", x.Tags["Model"])
+	assert.Equal("smc PENTAX-DA* 16-50mm F2.8 ED AL [IF] SDM", x.Tags["LensModel"])
+	assert.Equal(5.6, x.Tags["FNumber"])
+	assert.Equal(0.005, x.Tags["ExposureTime"])
+	assert.Equal(100.0, x.Tags["ISOSpeedRatings"])
+
+	// IPTC
+	assert.Equal([]interface{}{"Malaga", "Torremolinos"}, x.Tags["Keywords"])
+	assert.Equal("Benalmádena", x.Tags["City"])
+	assert.Equal("Spain", x.Tags["CountryName"])
+
+	_, err = f.Seek(0, 0)
+	assert.NoError(err)
+	assert.Equal(1, Orientation(f))
+}
+
+func TestExifFields(t *testing.T) {
+	assert := require.New(t)
+
+	f, err := os.Open(filepath.FromSlash("../testdata/sunset.jpg"))
+	assert.NoError(err)
+	defer f.Close()
+
+	d, err := NewDecoder(
+		IncludeFields("lens|city|Keywords"),
+		ExcludeFields("Specification"),
+		WithDateDisabled(true),
+		WithLatLongDisabled(true))
+	assert.NoError(err)
+
+	x, err := d.Decode(f)
+	assert.NoError(err)
+	assert.True(x.Date.IsZero())
+	assert.Equal(0.0, x.Lat)
+	assert.Len(x.Tags, 3)
+	assert.Contains(x.Tags, "LensModel")
+	assert.Contains(x.Tags, "City")
+	assert.Contains(x.Tags, "Keywords")
+
+	_, err = NewDecoder(IncludeFields("("))
+	assert.Error(err)
+}
+
+func TestExifNone(t *testing.T) {
+	assert := require.New(t)
+
+	f, err := os.Open(filepath.FromSlash("../testdata/gohugoio.png"))
+	assert.NoError(err)
+	defer f.Close()
+
+	d, err := NewDecoder()
+	assert.NoError(err)
+	x, err := d.Decode(f)
+	assert.NoError(err)
+	assert.Nil(x)
+}
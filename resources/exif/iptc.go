@@ -0,0 +1,207 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// The IPTC IIM application record (record 2) datasets we decode, see
+// https://www.iptc.org/std/IIM/4.2/specification/IIMV4.2.pdf
+var iptcDatasets = map[byte]string{
+	5:   "ObjectName",
+	15:  "Category",
+	20:  "SupplementalCategories",
+	25:  "Keywords",
+	40:  "SpecialInstructions",
+	55:  "DateCreated",
+	60:  "TimeCreated",
+	80:  "Byline",
+	85:  "BylineTitle",
+	90:  "City",
+	92:  "SubLocation",
+	95:  "ProvinceState",
+	100: "CountryCode",
+	101: "CountryName",
+	103: "OriginalTransmissionReference",
+	105: "Headline",
+	110: "Credit",
+	115: "Source",
+	116: "CopyrightNotice",
+	118: "Contact",
+	120: "Caption",
+	122: "WriterEditor",
+}
+
+// The repeatable datasets are decoded into slices.
+var iptcRepeatable = map[string]bool{
+	"SupplementalCategories": true,
+	"Keywords":               true,
+	"Byline":                 true,
+	"BylineTitle":            true,
+	"Contact":                true,
+	"WriterEditor":           true,
+}
+
+var (
+	photoshopSignature = []byte("Photoshop 3.0\x00")
+	iptcResourceID     = []byte("8BIM\x04\x04")
+)
+
+// decodeIPTC decodes the IPTC metadata stored in the Photoshop APP13 segment
+// of a JPEG image. It returns nil if there is none.
+func decodeIPTC(r io.Reader) map[string]interface{} {
+	segment := findAPP13Segment(bufio.NewReader(r))
+	if !bytes.HasPrefix(segment, photoshopSignature) {
+		return nil
+	}
+
+	// Walk the Photoshop image resource blocks looking for the IPTC block.
+	b := segment[len(photoshopSignature):]
+	for len(b) >= 12 && bytes.HasPrefix(b, []byte("8BIM")) {
+		isIPTC := bytes.HasPrefix(b, iptcResourceID)
+		b = b[6:]
+
+		// The resource name is a Pascal string padded to an even length.
+		nameLen := int(b[0]) + 1
+		if nameLen%2 != 0 {
+			nameLen++
+		}
+		if len(b) < nameLen+4 {
+			break
+		}
+		b = b[nameLen:]
+
+		size := int(binary.BigEndian.Uint32(b))
+		b = b[4:]
+		if size > len(b) {
+			break
+		}
+
+		if isIPTC {
+			return decodeIIM(b[:size])
+		}
+
+		if size%2 != 0 {
+			size++
+		}
+		if size > len(b) {
+			break
+		}
+		b = b[size:]
+	}
+
+	return nil
+}
+
+func decodeIIM(b []byte) map[string]interface{} {
+	m := make(map[string]interface{})
+
+	for len(b) >= 5 && b[0] == 0x1c {
+		record, dataset := b[1], b[2]
+		size := int(binary.BigEndian.Uint16(b[3:5]))
+		b = b[5:]
+		if size&0x8000 != 0 || size > len(b) {
+			// Extended datasets are not used for the text fields we need.
+			break
+		}
+		value := b[:size]
+		b = b[size:]
+
+		if record != 2 {
+			continue
+		}
+
+		name, found := iptcDatasets[dataset]
+		if !found {
+			continue
+		}
+
+		s := iptcString(value)
+		if iptcRepeatable[name] {
+			var values []interface{}
+			if v, ok := m[name]; ok {
+				values = v.([]interface{})
+			}
+			m[name] = append(values, s)
+		} else {
+			m[name] = s
+		}
+	}
+
+	return m
+}
+
+// iptcString decodes v as UTF-8, falling back to Latin-1 which was common in
+// older files.
+func iptcString(v []byte) string {
+	if utf8.Valid(v) {
+		return strings.TrimSpace(string(v))
+	}
+	runes := make([]rune, len(v))
+	for i, b := range v {
+		runes[i] = rune(b)
+	}
+	return strings.TrimSpace(string(runes))
+}
+
+// findAPP13Segment returns the content of the APP13 segment in the JPEG in r,
+// or nil if it is not a JPEG or there is no such segment.
+func findAPP13Segment(r *bufio.Reader) []byte {
+	var marker [2]byte
+	if _, err := io.ReadFull(r, marker[:]); err != nil || marker != [2]byte{0xff, 0xd8} {
+		return nil
+	}
+
+	for {
+		if _, err := io.ReadFull(r, marker[:]); err != nil {
+			return nil
+		}
+		if marker[0] != 0xff {
+			return nil
+		}
+
+		switch marker[1] {
+		case 0xd9, 0xda:
+			// End of image or start of scan, no more metadata.
+			return nil
+		}
+
+		var size [2]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return nil
+		}
+		n := int(binary.BigEndian.Uint16(size[:])) - 2
+		if n < 0 {
+			return nil
+		}
+
+		if marker[1] == 0xed {
+			segment := make([]byte, n)
+			if _, err := io.ReadFull(r, segment); err != nil {
+				return nil
+			}
+			return segment
+		}
+
+		if _, err := r.Discard(n); err != nil {
+			return nil
+		}
+	}
+}
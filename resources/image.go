@@ -21,7 +21,9 @@ import (
 	"image/draw"
 	"image/jpeg"
 	"io"
+	"mime"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,14 +35,14 @@ import (
 	"github.com/disintegration/imaging"
 	"github.com/gohugoio/hugo/common/hugio"
 	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/media"
+	"github.com/gohugoio/hugo/resources/exif"
 	"github.com/mitchellh/mapstructure"
 
 	// Blind import for image.Decode
+	_ "golang.org/x/image/webp"
 	_ "image/gif"
 	_ "image/png"
-
-	// Blind import for image.Decode
-	_ "golang.org/x/image/webp"
 )
 
 var (
@@ -52,7 +54,7 @@ var (
 // Imaging contains default image processing configuration. This will be fetched
 // from site (or language) config.
 type Imaging struct {
-	// Default image quality setting (1-100). Only used for JPEG images.
+	// Default image quality setting (1-100). Only used for JPEG and WebP images.
 	Quality int
 
 	// Resample filter used. See https://github.com/disintegration/imaging
@@ -60,13 +62,50 @@ type Imaging struct {
 
 	// The anchor used in Fill. Default is "smart", i.e. Smart Crop.
 	Anchor string
+
+	// Apply the EXIF orientation to processed images, so photos
+	// taken with e.g. a phone are not rendered sideways.
+	AutoOrient bool
+
+	// Configures the EXIF and IPTC decoding.
+	Exif ExifConfig
+
+	// The number of images to process in parallel. Default is the number of
+	// CPUs. Note that the imaging library also spins up its own set of
+	// Go routines per image.
+	Workers int
+
+	// The approximate memory in megabytes to use for the images processed
+	// in parallel. Images needing more are processed one at a time.
+	MemoryLimit int
+}
+
+// ExifConfig configures what metadata to decode from images.
+type ExifConfig struct {
+	// Regexp matching the tags to include, e.g. "FNumber|Exposure|Lens".
+	// Default is to include all.
+	IncludeFields string
+
+	// Regexp matching the tags to exclude, e.g. "GPS|Maker".
+	ExcludeFields string
+
+	// Do not decode the capture date.
+	DisableDate bool
+
+	// Do not decode the GPS coordinates.
+	DisableLatLong bool
 }
 
 const (
-	defaultJPEGQuality    = 75
-	defaultResampleFilter = "box"
+	defaultJPEGQuality        = 75
+	defaultResampleFilter     = "box"
+	defaultImagingMemoryLimit = 4096
 )
 
+// webpFormat is the WebP image format, which the imaging library does not
+// know about. Note that encoding to WebP requires the extended Hugo version.
+const webpFormat imaging.Format = -1
+
 var (
 	imageFormats = map[string]imaging.Format{
 		".jpg":  imaging.JPEG,
@@ -76,6 +115,7 @@ var (
 		".tiff": imaging.TIFF,
 		".bmp":  imaging.BMP,
 		".gif":  imaging.GIF,
+		".webp": webpFormat,
 	}
 
 	// Add or increment if changes to an image format's processing requires
@@ -129,6 +169,16 @@ type Image struct {
 
 	format imaging.Format
 
+	exifInit sync.Once
+	exif     *exif.Exif
+	exifErr  error
+
+	orientationInit sync.Once
+	orientation     int
+
+	// Set in the page resources metadata.
+	focalPoint *focalPoint
+
 	*genericResource
 }
 
@@ -149,6 +199,7 @@ func (i *Image) WithNewBase(base string) resource.Resource {
 	return &Image{
 		imaging:         i.imaging,
 		format:          i.format,
+		focalPoint:      i.focalPoint,
 		genericResource: i.genericResource.WithNewBase(base).(*genericResource)}
 }
 
@@ -170,10 +221,14 @@ func (i *Image) Fit(spec string) (*Image, error) {
 }
 
 // Fill scales the image to the smallest possible size that will cover the specified dimensions,
-// crops the resized image to the specified dimensions using the given anchor point.
+// crops the resized image to the specified dimensions using the given anchor point
+// or the focal point of the image.
 // Space delimited config: 200x300 TopLeft
 func (i *Image) Fill(spec string) (*Image, error) {
 	return i.doWithImageConfig("fill", spec, func(src image.Image, conf imageConfig) (image.Image, error) {
+		if conf.FocalPoint != nil {
+			return conf.FocalPoint.fill(src, conf.Width, conf.Height, conf.Filter), nil
+		}
 		if conf.AnchorStr == smartCropIdentifier {
 			return smartCrop(src, conf.Width, conf.Height, conf.Anchor, conf.Filter)
 		}
@@ -181,12 +236,51 @@ func (i *Image) Fill(spec string) (*Image, error) {
 	})
 }
 
+// Crop crops the image to the specified dimensions without resizing, using the given
+// anchor point or the focal point of the image.
+// Space delimited config: 200x300 TopLeft
+func (i *Image) Crop(spec string) (*Image, error) {
+	return i.doWithImageConfig("crop", spec, func(src image.Image, conf imageConfig) (image.Image, error) {
+		if conf.FocalPoint != nil {
+			return imaging.Crop(src, conf.FocalPoint.rect(src.Bounds(), conf.Width, conf.Height)), nil
+		}
+		if conf.AnchorStr == smartCropIdentifier {
+			return smartCropNoResize(src, conf.Width, conf.Height, conf.Filter)
+		}
+		return imaging.CropAnchor(src, conf.Width, conf.Height, conf.Anchor), nil
+	})
+}
+
+// Filter applies the given filters, in order, to the image and returns the
+// filtered image. The filters can also be given as slices of filters.
+func (i *Image) Filter(filters ...interface{}) (*Image, error) {
+	ff, err := toImageFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(ff) == 0 {
+		return nil, errors.New("must provide one or more filters")
+	}
+
+	conf := imageConfig{Action: "filter", Filters: ff}
+
+	return i.doWithConfig(conf, func(src image.Image, conf imageConfig) (image.Image, error) {
+		var err error
+		for _, f := range conf.Filters {
+			if src, err = f.Apply(src); err != nil {
+				return nil, err
+			}
+		}
+		return src, nil
+	})
+}
+
 // Holds configuration to create a new image from an existing one, resize etc.
 type imageConfig struct {
 	Action string
 
 	// Quality ranges from 1 to 100 inclusive, higher is better.
-	// This is only relevant for JPEG images.
+	// This is only relevant for JPEG and WebP images.
 	// Default is 75.
 	Quality int
 
@@ -202,21 +296,35 @@ type imageConfig struct {
 
 	Anchor    imaging.Anchor
 	AnchorStr string
-}
 
-func (i *Image) isJPEG() bool {
-	name := strings.ToLower(i.relTargetDirFile.file)
-	return strings.HasSuffix(name, ".jpg") || strings.HasSuffix(name, ".jpeg")
+	// The format to convert the image to, e.g. "webp". If not set, the
+	// image keeps its original format.
+	TargetFormat    imaging.Format
+	TargetFormatStr string
+
+	// The filters to apply in the "filter" action.
+	Filters []ImageFilter
+
+	// The EXIF orientation to apply before any other processing. Only set
+	// if imaging.autoOrient is enabled.
+	Orientation int
+
+	// Used instead of the anchor in Fill and Crop if set.
+	FocalPoint *focalPoint
 }
 
-// Serialize image processing. The imaging library spins up its own set of Go routines,
-// so there is not much to gain from adding more load to the mix. That
-// can even have negative effect in low resource scenarios.
-// Note that this only effects the non-cached scenario. Once the processed
-// image is written to disk, everything is fast, fast fast.
-const imageProcWorkers = 1
+// targetFormat returns the format of the image created from i with the given
+// config.
+func (i *Image) targetFormat(conf imageConfig) imaging.Format {
+	if conf.TargetFormatStr != "" {
+		return conf.TargetFormat
+	}
+	return i.format
+}
 
-var imageProcSem = make(chan bool, imageProcWorkers)
+func formatSupportsQuality(format imaging.Format) bool {
+	return format == imaging.JPEG || format == webpFormat
+}
 
 func (i *Image) doWithImageConfig(action, spec string, f func(src image.Image, conf imageConfig) (image.Image, error)) (*Image, error) {
 	conf, err := parseImageConfig(spec)
@@ -225,9 +333,10 @@ func (i *Image) doWithImageConfig(action, spec string, f func(src image.Image, c
 	}
 	conf.Action = action
 
-	if conf.Quality <= 0 && i.isJPEG() {
-		// We need a quality setting for all JPEGs
-		conf.Quality = i.imaging.Quality
+	cropping := action == "fill" || action == "crop"
+
+	if action == "crop" && (conf.Width == 0 || conf.Height == 0) {
+		return nil, errors.New("must provide Width and Height")
 	}
 
 	if conf.FilterStr == "" {
@@ -236,30 +345,57 @@ func (i *Image) doWithImageConfig(action, spec string, f func(src image.Image, c
 	}
 
 	if conf.AnchorStr == "" {
-		conf.AnchorStr = i.imaging.Anchor
-		if !strings.EqualFold(conf.AnchorStr, smartCropIdentifier) {
-			conf.Anchor = anchorPositions[conf.AnchorStr]
+		if cropping && i.focalPoint != nil {
+			// An anchor in the spec takes precedence over the focal point.
+			conf.FocalPoint = i.focalPoint
+		} else {
+			conf.AnchorStr = i.imaging.Anchor
+			if !strings.EqualFold(conf.AnchorStr, smartCropIdentifier) {
+				conf.Anchor = anchorPositions[conf.AnchorStr]
+			}
 		}
 	}
 
-	return i.spec.imageCache.getOrCreate(i, conf, func() (*Image, image.Image, error) {
-		imageProcSem <- true
-		defer func() {
-			<-imageProcSem
-		}()
+	return i.doWithConfig(conf, f)
+}
+
+func (i *Image) doWithConfig(conf imageConfig, f func(src image.Image, conf imageConfig) (image.Image, error)) (*Image, error) {
+	if i.targetFormat(conf) == webpFormat && !webpEncodingAvailable {
+		if conf.TargetFormatStr != "" {
+			return nil, errors.New("converting images to WebP is only available in the extended Hugo version")
+		}
+		// Keep the image lossless.
+		conf.TargetFormat = imaging.PNG
+		conf.TargetFormatStr = "png"
+	}
+
+	if conf.Quality <= 0 && formatSupportsQuality(i.targetFormat(conf)) {
+		// We need a quality setting for all JPEGs and WebPs
+		conf.Quality = i.imaging.Quality
+	}
+
+	if i.imaging.AutoOrient {
+		conf.Orientation = i.exifOrientation()
+	}
 
+	return i.spec.imageCache.getOrCreate(i, conf, func() (*Image, image.Image, error) {
 		ci := i.clone()
 
-		errOp := action
+		errOp := conf.Action
 		errPath := i.sourceFilename
 
 		ci.setBasePath(conf)
+		ci.updateFromConfig(conf)
 
 		src, err := i.decodeSource()
 		if err != nil {
 			return nil, nil, &os.PathError{Op: errOp, Path: errPath, Err: err}
 		}
 
+		if conf.Orientation > 1 {
+			src = applyOrientation(src, conf.Orientation)
+		}
+
 		if conf.Rotate != 0 {
 			// Rotate it before any scaling to get the dimensions correct.
 			src = imaging.Rotate(src, float64(conf.Rotate), color.Transparent)
@@ -270,7 +406,7 @@ func (i *Image) doWithImageConfig(action, spec string, f func(src image.Image, c
 			return ci, nil, &os.PathError{Op: errOp, Path: errPath, Err: err}
 		}
 
-		if i.format == imaging.PNG {
+		if ci.format == imaging.PNG {
 			// Apply the colour palette from the source
 			if paletted, ok := src.(*image.Paletted); ok {
 				tmp := image.NewPaletted(converted.Bounds(), paletted.Palette)
@@ -289,25 +425,51 @@ func (i *Image) doWithImageConfig(action, spec string, f func(src image.Image, c
 }
 
 func (i imageConfig) key(format imaging.Format) string {
-	k := strconv.Itoa(i.Width) + "x" + strconv.Itoa(i.Height)
-	if i.Action != "" {
-		k += "_" + i.Action
-	}
-	if i.Quality > 0 {
-		k += "_q" + strconv.Itoa(i.Quality)
-	}
-	if i.Rotate != 0 {
-		k += "_r" + strconv.Itoa(i.Rotate)
-	}
-	anchor := i.AnchorStr
-	if anchor == smartCropIdentifier {
-		anchor = anchor + strconv.Itoa(smartCropVersionNumber)
+	var k string
+
+	if len(i.Filters) > 0 {
+		// The filter keys can get long, e.g. for overlays.
+		filterKeys := make([]string, len(i.Filters))
+		for j, f := range i.Filters {
+			filterKeys[j] = f.Key()
+		}
+		k = i.Action + "_" + helpers.MD5String(strings.Join(filterKeys, "|"))
+		if i.Quality > 0 {
+			k += "_q" + strconv.Itoa(i.Quality)
+		}
+	} else {
+		k = strconv.Itoa(i.Width) + "x" + strconv.Itoa(i.Height)
+		if i.Action != "" {
+			k += "_" + i.Action
+		}
+		if i.Quality > 0 {
+			k += "_q" + strconv.Itoa(i.Quality)
+		}
+		if i.Rotate != 0 {
+			k += "_r" + strconv.Itoa(i.Rotate)
+		}
+		anchor := i.AnchorStr
+		if anchor == smartCropIdentifier {
+			anchor = anchor + strconv.Itoa(smartCropVersionNumber)
+		}
+
+		k += "_" + i.FilterStr
+
+		if strings.EqualFold(i.Action, "fill") || strings.EqualFold(i.Action, "crop") {
+			if i.FocalPoint != nil {
+				k += "_fp" + i.FocalPoint.String()
+			} else {
+				k += "_" + anchor
+			}
+		}
 	}
 
-	k += "_" + i.FilterStr
+	if i.Orientation > 1 {
+		k += "_o" + strconv.Itoa(i.Orientation)
+	}
 
-	if strings.EqualFold(i.Action, "fill") {
-		k += "_" + anchor
+	if i.TargetFormatStr != "" {
+		k += "_" + i.TargetFormatStr
 	}
 
 	if v, ok := imageFormatsVersions[format]; ok {
@@ -370,6 +532,9 @@ func parseImageConfig(config string) (imageConfig, error) {
 		} else if filter, ok := imageFilters[part]; ok {
 			c.Filter = filter
 			c.FilterStr = part
+		} else if format, ok := imageFormats["."+part]; ok {
+			c.TargetFormat = format
+			c.TargetFormatStr = part
 		} else if part[0] == 'q' {
 			c.Quality, err = strconv.Atoi(part[1:])
 			if err != nil {
@@ -502,6 +667,8 @@ func (i *Image) encodeTo(conf imageConfig, img image.Image, w io.Writer) error {
 			return jpeg.Encode(w, rgba, &jpeg.Options{Quality: quality})
 		}
 		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	case webpFormat:
+		return encodeWebP(w, img, conf.Quality)
 	default:
 		return imaging.Encode(w, img, i.format)
 	}
@@ -517,6 +684,7 @@ func (i *Image) clone() *Image {
 	return &Image{
 		imaging:         i.imaging,
 		format:          i.format,
+		focalPoint:      i.focalPoint,
 		genericResource: &g}
 }
 
@@ -524,6 +692,33 @@ func (i *Image) setBasePath(conf imageConfig) {
 	i.relTargetDirFile = i.relTargetPathFromConfig(conf)
 }
 
+// updateFromConfig updates the image i created with conf.
+func (i *Image) updateFromConfig(conf imageConfig) {
+	// The focal point, in percent, is still valid for scaled images.
+	if conf.Action == "fill" || conf.Action == "crop" || conf.Rotate != 0 || conf.Orientation > 1 {
+		i.focalPoint = nil
+	}
+
+	i.setTargetFormat(conf)
+}
+
+// setTargetFormat sets the format and media type of i to the target format
+// in conf, if any.
+func (i *Image) setTargetFormat(conf imageConfig) {
+	if conf.TargetFormatStr == "" {
+		return
+	}
+
+	i.format = conf.TargetFormat
+
+	mediaType, found := i.spec.MediaTypes.GetFirstBySuffix(conf.TargetFormatStr)
+	if !found {
+		// Not all image formats are in the default media types.
+		mediaType, _ = media.FromStringAndExt(mime.TypeByExtension("."+conf.TargetFormatStr), conf.TargetFormatStr)
+	}
+	i.mediaType = mediaType
+}
+
 func (i *Image) relTargetPathFromConfig(conf imageConfig) dirFile {
 	p1, p2 := helpers.FileAndExt(i.relTargetDirFile.file)
 
@@ -532,7 +727,11 @@ func (i *Image) relTargetPathFromConfig(conf imageConfig) dirFile {
 	// Do not change for no good reason.
 	const md5Threshold = 100
 
-	key := conf.key(i.format)
+	key := conf.key(i.targetFormat(conf))
+
+	if conf.TargetFormatStr != "" {
+		p2 = "." + conf.TargetFormatStr
+	}
 
 	// It is useful to have the key in clear text, but when nesting transforms, it
 	// can easily be too long to read, and maybe even too long
@@ -572,6 +771,18 @@ func decodeImaging(m map[string]interface{}) (Imaging, error) {
 		return i, errors.New("JPEG quality must be a number between 1 and 100")
 	}
 
+	if i.Workers == 0 {
+		i.Workers = runtime.NumCPU()
+	} else if i.Workers < 0 {
+		return i, errors.New("the number of imaging workers cannot be negative")
+	}
+
+	if i.MemoryLimit == 0 {
+		i.MemoryLimit = defaultImagingMemoryLimit
+	} else if i.MemoryLimit < 0 {
+		return i, errors.New("imaging memory limit cannot be negative")
+	}
+
 	if i.Anchor == "" || strings.EqualFold(i.Anchor, smartCropIdentifier) {
 		i.Anchor = smartCropIdentifier
 	} else {
@@ -90,6 +90,7 @@ func (c *imageCache) getOrCreate(
 	read := func(info filecache.ItemInfo, r io.Reader) error {
 		img = parent.clone()
 		img.relTargetDirFile.file = relTarget.file
+		img.updateFromConfig(conf)
 		img.sourceFilename = info.Name
 
 		w, err := img.openDestinationsForWriting()
@@ -109,6 +110,13 @@ func (c *imageCache) getOrCreate(
 
 	// create creates the image and encodes it to w (cache) and to its destinations.
 	create := func(info filecache.ItemInfo, w io.WriteCloser) (err error) {
+		release, err := parent.spec.imageProcessing.acquire(parent.processingMemory())
+		if err != nil {
+			w.Close()
+			return err
+		}
+		defer release()
+
 		var conv image.Image
 		img, conv, err = createImage()
 		if err != nil {
@@ -0,0 +1,117 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+	"path"
+
+	"github.com/disintegration/imaging"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/resources/exif"
+)
+
+// Exif returns the EXIF and IPTC metadata of the image, or nil if it has
+// none. The metadata is decoded once and stored in the image file cache.
+// Note that processed images have no metadata.
+func (i *Image) Exif() (*exif.Exif, error) {
+	i.exifInit.Do(func() {
+		if !i.supportsExif() {
+			return
+		}
+		i.exif, i.exifErr = i.getOrDecodeExif()
+	})
+	return i.exif, i.exifErr
+}
+
+func (i *Image) supportsExif() bool {
+	return i.format == imaging.JPEG || i.format == imaging.TIFF
+}
+
+func (i *Image) getOrDecodeExif() (*exif.Exif, error) {
+	p1, _ := helpers.FileAndExt(i.relTargetDirFile.file)
+
+	// The decoded metadata depends on the imaging.exif config.
+	confHash := helpers.MD5String(fmt.Sprintf("%+v", i.imaging.Exif))
+	key := path.Join(i.relTargetDirFile.dir, fmt.Sprintf("%s_hu%s_%d_exif_%s.json", p1, i.hash, i.osFileInfo.Size(), confHash))
+
+	_, b, err := i.spec.FileCaches.ImageCache().GetOrCreateBytes(key, func() ([]byte, error) {
+		f, err := i.ReadSeekCloser()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		x, err := i.spec.exifDecoder.Decode(f)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(x)
+	})
+	if err != nil {
+		return nil, &os.PathError{Op: "exif", Path: i.sourceFilename, Err: err}
+	}
+
+	// Always return what is stored in the cache, so the value types are
+	// the same with or without a cached file.
+	var x *exif.Exif
+	if err := json.Unmarshal(b, &x); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+// exifOrientation returns the EXIF orientation of the image, 1 if not set.
+func (i *Image) exifOrientation() int {
+	i.orientationInit.Do(func() {
+		i.orientation = 1
+		if !i.supportsExif() {
+			return
+		}
+		f, err := i.ReadSeekCloser()
+		if err != nil {
+			return
+		}
+		defer f.Close()
+		i.orientation = exif.Orientation(f)
+	})
+	return i.orientation
+}
+
+// applyOrientation transforms src so it is displayed upright given its EXIF
+// orientation.
+func applyOrientation(src image.Image, orientation int) image.Image {
+	switch orientation {
+	case 2:
+		return imaging.FlipH(src)
+	case 3:
+		return imaging.Rotate180(src)
+	case 4:
+		return imaging.FlipV(src)
+	case 5:
+		return imaging.Transpose(src)
+	case 6:
+		return imaging.Rotate270(src)
+	case 7:
+		return imaging.Transverse(src)
+	case 8:
+		return imaging.Rotate90(src)
+	}
+	return src
+}
@@ -0,0 +1,331 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/pkg/errors"
+)
+
+// ImageFilter is an image filter that can be applied with Image.Filter.
+// Create them with the constructors below, e.g. BlurFilter.
+type ImageFilter interface {
+	// Key identifies the filter and its options. It is used to build the
+	// file name of the filtered image.
+	Key() string
+
+	// Apply applies the filter to src.
+	Apply(src image.Image) (image.Image, error)
+}
+
+type imageFilter struct {
+	key   string
+	apply func(src image.Image) (image.Image, error)
+}
+
+func (f imageFilter) Key() string {
+	return f.key
+}
+
+func (f imageFilter) Apply(src image.Image) (image.Image, error) {
+	return f.apply(src)
+}
+
+func newImageFilter(name string, fn func(src image.Image) image.Image, options ...float64) ImageFilter {
+	return imageFilter{
+		key: filterKey(name, options...),
+		apply: func(src image.Image) (image.Image, error) {
+			return fn(src), nil
+		},
+	}
+}
+
+func filterKey(name string, options ...float64) string {
+	parts := []string{name}
+	for _, o := range options {
+		parts = append(parts, strconv.FormatFloat(o, 'f', -1, 64))
+	}
+	return strings.Join(parts, "_")
+}
+
+// GrayscaleFilter creates a filter that produces a grayscale version of the
+// image.
+func GrayscaleFilter() ImageFilter {
+	return newImageFilter("grayscale", func(src image.Image) image.Image {
+		return imaging.Grayscale(src)
+	})
+}
+
+// InvertFilter creates a filter that negates the colors of the image.
+func InvertFilter() ImageFilter {
+	return newImageFilter("invert", func(src image.Image) image.Image {
+		return imaging.Invert(src)
+	})
+}
+
+// BlurFilter creates a filter that applies a gaussian blur to the image.
+// Sigma must be positive and indicates how much the image will be blurred.
+func BlurFilter(sigma float64) (ImageFilter, error) {
+	if sigma <= 0 {
+		return nil, errors.New("blur sigma must be positive")
+	}
+	return newImageFilter("blur", func(src image.Image) image.Image {
+		return imaging.Blur(src, sigma)
+	}, sigma), nil
+}
+
+// SharpenFilter creates a filter that sharpens the image. Sigma must be
+// positive and indicates how much the image will be sharpened.
+func SharpenFilter(sigma float64) (ImageFilter, error) {
+	if sigma <= 0 {
+		return nil, errors.New("sharpen sigma must be positive")
+	}
+	return newImageFilter("sharpen", func(src image.Image) image.Image {
+		return imaging.Sharpen(src, sigma)
+	}, sigma), nil
+}
+
+// BrightnessFilter creates a filter that changes the brightness of the image.
+// The percentage must be in range (-100, 100).
+func BrightnessFilter(percentage float64) (ImageFilter, error) {
+	if err := checkRange("brightness", percentage, -100, 100); err != nil {
+		return nil, err
+	}
+	return newImageFilter("brightness", func(src image.Image) image.Image {
+		return imaging.AdjustBrightness(src, percentage)
+	}, percentage), nil
+}
+
+// ContrastFilter creates a filter that changes the contrast of the image.
+// The percentage must be in range (-100, 100).
+func ContrastFilter(percentage float64) (ImageFilter, error) {
+	if err := checkRange("contrast", percentage, -100, 100); err != nil {
+		return nil, err
+	}
+	return newImageFilter("contrast", func(src image.Image) image.Image {
+		return imaging.AdjustContrast(src, percentage)
+	}, percentage), nil
+}
+
+// SaturationFilter creates a filter that changes the saturation of the image.
+// The percentage must be in range (-100, 500).
+func SaturationFilter(percentage float64) (ImageFilter, error) {
+	if err := checkRange("saturation", percentage, -100, 500); err != nil {
+		return nil, err
+	}
+	return newImageFilter("saturation", func(src image.Image) image.Image {
+		return imaging.AdjustSaturation(src, percentage)
+	}, percentage), nil
+}
+
+// GammaFilter creates a filter that applies a gamma correction to the image.
+// Gamma must be positive. A gamma of 1 leaves the image unchanged, a gamma
+// below 1 darkens it and a gamma above 1 lightens it.
+func GammaFilter(gamma float64) (ImageFilter, error) {
+	if gamma <= 0 {
+		return nil, errors.New("gamma must be positive")
+	}
+	return newImageFilter("gamma", func(src image.Image) image.Image {
+		return imaging.AdjustGamma(src, gamma)
+	}, gamma), nil
+}
+
+// SepiaFilter creates a filter that produces a sepia-toned version of the
+// image. The percentage must be in range [0, 100].
+func SepiaFilter(percentage float64) (ImageFilter, error) {
+	if err := checkRange("sepia", percentage, 0, 100); err != nil {
+		return nil, err
+	}
+	return newImageFilter("sepia", func(src image.Image) image.Image {
+		return imaging.AdjustFunc(src, func(c color.NRGBA) color.NRGBA {
+			r, g, b := float64(c.R), float64(c.G), float64(c.B)
+			return blendNRGBA(c, color.NRGBA{
+				R: clampUint8(0.393*r + 0.769*g + 0.189*b),
+				G: clampUint8(0.349*r + 0.686*g + 0.168*b),
+				B: clampUint8(0.272*r + 0.534*g + 0.131*b),
+				A: c.A,
+			}, percentage)
+		})
+	}, percentage), nil
+}
+
+// ColorizeFilter creates a filter that produces a colorized version of the
+// image. The hue is in range [0, 360), the saturation and the percentage
+// (the strength of the effect) in range [0, 100].
+func ColorizeFilter(hue, saturation, percentage float64) (ImageFilter, error) {
+	if err := checkRange("colorize hue", hue, 0, 360); err != nil {
+		return nil, err
+	}
+	if err := checkRange("colorize saturation", saturation, 0, 100); err != nil {
+		return nil, err
+	}
+	if err := checkRange("colorize percentage", percentage, 0, 100); err != nil {
+		return nil, err
+	}
+	return newImageFilter("colorize", func(src image.Image) image.Image {
+		return imaging.AdjustFunc(src, func(c color.NRGBA) color.NRGBA {
+			l := lightness(c)
+			r, g, b := hslToRGB(hue/360, saturation/100, l)
+			return blendNRGBA(c, color.NRGBA{
+				R: clampUint8(r * 255),
+				G: clampUint8(g * 255),
+				B: clampUint8(b * 255),
+				A: c.A,
+			}, percentage)
+		})
+	}, hue, saturation, percentage), nil
+}
+
+// PixelateFilter creates a filter that pixelates the image into blocks of
+// the given size in pixels.
+func PixelateFilter(size int) (ImageFilter, error) {
+	if size < 1 {
+		return nil, errors.New("pixelate size must be at least 1")
+	}
+	return newImageFilter("pixelate", func(src image.Image) image.Image {
+		b := src.Bounds()
+		w, h := b.Dx()/size, b.Dy()/size
+		if w < 1 {
+			w = 1
+		}
+		if h < 1 {
+			h = 1
+		}
+		small := imaging.Resize(src, w, h, imaging.Box)
+		return imaging.Resize(small, b.Dx(), b.Dy(), imaging.NearestNeighbor)
+	}, float64(size)), nil
+}
+
+// OverlayFilter creates a filter that draws the image img on top of the
+// image at the given position, e.g. a watermark. The opacity must be in
+// range [0, 1].
+func OverlayFilter(img *Image, x, y int, opacity float64) (ImageFilter, error) {
+	if img == nil {
+		return nil, errors.New("overlay needs an image")
+	}
+	if err := checkRange("overlay opacity", opacity, 0, 1); err != nil {
+		return nil, err
+	}
+
+	key := filterKey("overlay", float64(x), float64(y), opacity)
+	key += "_" + img.hash + "_" + img.relTargetDirFile.path()
+
+	return imageFilter{
+		key: key,
+		apply: func(src image.Image) (image.Image, error) {
+			overlay, err := img.decodeSource()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to decode overlay image")
+			}
+			return imaging.Overlay(src, overlay, image.Pt(x, y), opacity), nil
+		},
+	}, nil
+}
+
+// toImageFilters flattens the given filters and slices of filters.
+func toImageFilters(filters []interface{}) ([]ImageFilter, error) {
+	var ff []ImageFilter
+	for _, f := range filters {
+		switch v := f.(type) {
+		case ImageFilter:
+			ff = append(ff, v)
+		case []ImageFilter:
+			ff = append(ff, v...)
+		case []interface{}:
+			vv, err := toImageFilters(v)
+			if err != nil {
+				return nil, err
+			}
+			ff = append(ff, vv...)
+		default:
+			return nil, fmt.Errorf("%T is not an image filter", f)
+		}
+	}
+	return ff, nil
+}
+
+func checkRange(name string, v, min, max float64) error {
+	if v < min || v > max {
+		return fmt.Errorf("%s must be in range [%v, %v]", name, min, max)
+	}
+	return nil
+}
+
+// blendNRGBA blends from c1 to c2 by the given percentage.
+func blendNRGBA(c1, c2 color.NRGBA, percentage float64) color.NRGBA {
+	p := percentage / 100
+	blend := func(v1, v2 uint8) uint8 {
+		return clampUint8(float64(v1) + (float64(v2)-float64(v1))*p)
+	}
+	return color.NRGBA{
+		R: blend(c1.R, c2.R),
+		G: blend(c1.G, c2.G),
+		B: blend(c1.B, c2.B),
+		A: c1.A,
+	}
+}
+
+func clampUint8(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
+
+// lightness returns the HSL lightness of c in range [0, 1].
+func lightness(c color.NRGBA) float64 {
+	max := math.Max(float64(c.R), math.Max(float64(c.G), float64(c.B)))
+	min := math.Min(float64(c.R), math.Min(float64(c.G), float64(c.B)))
+	return (max + min) / 2 / 255
+}
+
+// hslToRGB converts the HSL color, all in range [0, 1], to RGB values in
+// range [0, 1].
+func hslToRGB(h, s, l float64) (float64, float64, float64) {
+	if s == 0 {
+		return l, l, l
+	}
+
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+
+	hueToRGB := func(t float64) float64 {
+		if t < 0 {
+			t++
+		}
+		if t > 1 {
+			t--
+		}
+		switch {
+		case t < 1.0/6:
+			return p + (q-p)*6*t
+		case t < 1.0/2:
+			return q
+		case t < 2.0/3:
+			return p + (q-p)*(2.0/3-t)*6
+		}
+		return p
+	}
+
+	return hueToRGB(h + 1.0/3), hueToRGB(h), hueToRGB(h - 1.0/3)
+}
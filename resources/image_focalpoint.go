@@ -0,0 +1,136 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// focalPoint is the point of interest in an image, e.g. the subject of a
+// photo, in percent of the width and height from the top left corner. It is
+// set with focalPoint in the page resources metadata.
+type focalPoint struct {
+	X float64
+	Y float64
+}
+
+func (f focalPoint) String() string {
+	return strconv.FormatFloat(f.X, 'f', -1, 64) + "x" + strconv.FormatFloat(f.Y, 'f', -1, 64)
+}
+
+// parseFocalPoint parses a focal point given as a slice, e.g. [30, 60], or as
+// a string, e.g. "30,60" or "30% 60%".
+func parseFocalPoint(v interface{}) (*focalPoint, error) {
+	var parts []interface{}
+
+	switch vv := v.(type) {
+	case []interface{}:
+		parts = vv
+	case []float64:
+		for _, f := range vv {
+			parts = append(parts, f)
+		}
+	case []int:
+		for _, i := range vv {
+			parts = append(parts, i)
+		}
+	case string:
+		for _, s := range strings.FieldsFunc(vv, func(r rune) bool { return r == ',' || r == ' ' }) {
+			parts = append(parts, strings.TrimSuffix(s, "%"))
+		}
+	default:
+		return nil, fmt.Errorf("invalid focal point type %T", v)
+	}
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("focal point must have an x and an y value, got %v", v)
+	}
+
+	var fp focalPoint
+	for i, p := range []*float64{&fp.X, &fp.Y} {
+		f, err := cast.ToFloat64E(parts[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid focal point")
+		}
+		if f < 0 || f > 100 {
+			return nil, errors.New("focal point values must be in range [0, 100]")
+		}
+		*p = f
+	}
+
+	return &fp, nil
+}
+
+// rect returns the rectangle with the given size inside b, centered on the
+// focal point as far as possible.
+func (f focalPoint) rect(b image.Rectangle, width, height int) image.Rectangle {
+	if width > b.Dx() {
+		width = b.Dx()
+	}
+	if height > b.Dy() {
+		height = b.Dy()
+	}
+
+	center := func(min, size, length int, percent float64) int {
+		c := min + int(math.Round(float64(size)*percent/100))
+		start := c - length/2
+		if start < min {
+			start = min
+		}
+		if start+length > min+size {
+			start = min + size - length
+		}
+		return start
+	}
+
+	x0 := center(b.Min.X, b.Dx(), width, f.X)
+	y0 := center(b.Min.Y, b.Dy(), height, f.Y)
+
+	return image.Rect(x0, y0, x0+width, y0+height)
+}
+
+// fill scales src to the smallest size covering the given dimensions and
+// crops it around the focal point.
+func (f focalPoint) fill(src image.Image, width, height int, filter imaging.ResampleFilter) image.Image {
+	b := src.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+
+	if width <= 0 || height <= 0 || srcW <= 0 || srcH <= 0 {
+		return &image.NRGBA{}
+	}
+
+	// The largest rectangle in src with the target aspect ratio.
+	cropW, cropH := srcW, srcH
+	if srcW*height > srcH*width {
+		cropW = int(math.Round(float64(srcH) * float64(width) / float64(height)))
+	} else {
+		cropH = int(math.Round(float64(srcW) * float64(height) / float64(width)))
+	}
+
+	cropped := imaging.Crop(src, f.rect(b, cropW, cropH))
+
+	return imaging.Resize(cropped, width, height, filter)
+}
+
+func (i *Image) setFocalPoint(fp *focalPoint) {
+	i.focalPoint = fp
+}
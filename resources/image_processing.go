@@ -0,0 +1,125 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"image/color"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gohugoio/hugo/common/loggers"
+	"golang.org/x/sync/semaphore"
+)
+
+// How often to report progress while processing images.
+const imageProgressInterval = 5 * time.Second
+
+// imageProcessing limits the number of images processed in parallel and
+// the memory used by them, and reports the progress in the build output.
+// Note that this only effects the non-cached scenario. Once the processed
+// image is written to disk, everything is fast, fast fast.
+type imageProcessing struct {
+	workers chan bool
+
+	memory      *semaphore.Weighted
+	memoryLimit int64
+
+	logger *loggers.Logger
+
+	inProgress int64
+	done       uint64
+
+	progressMu sync.Mutex
+	lastReport time.Time
+}
+
+func newImageProcessing(imaging *Imaging, logger *loggers.Logger) *imageProcessing {
+	memoryLimit := int64(imaging.MemoryLimit) * 1024 * 1024
+
+	return &imageProcessing{
+		workers:     make(chan bool, imaging.Workers),
+		memory:      semaphore.NewWeighted(memoryLimit),
+		memoryLimit: memoryLimit,
+		logger:      logger,
+	}
+}
+
+// acquire blocks until there is a free worker and enough memory to process
+// an image estimated to need the given amount of memory in bytes. Images
+// needing more than the memory limit are processed alone.
+// The returned func must be called when done.
+func (p *imageProcessing) acquire(memory int64) (func(), error) {
+	if memory > p.memoryLimit {
+		memory = p.memoryLimit
+	}
+
+	if err := p.memory.Acquire(context.Background(), memory); err != nil {
+		return nil, err
+	}
+	p.workers <- true
+
+	p.start()
+
+	return func() {
+		<-p.workers
+		p.memory.Release(memory)
+		p.finish()
+	}, nil
+}
+
+func (p *imageProcessing) start() {
+	if atomic.AddInt64(&p.inProgress, 1) == 1 {
+		p.progressMu.Lock()
+		if p.lastReport.IsZero() {
+			p.lastReport = time.Now()
+		}
+		p.progressMu.Unlock()
+	}
+}
+
+func (p *imageProcessing) finish() {
+	inProgress := atomic.AddInt64(&p.inProgress, -1)
+	done := atomic.AddUint64(&p.done, 1)
+
+	p.progressMu.Lock()
+	if time.Since(p.lastReport) < imageProgressInterval {
+		p.progressMu.Unlock()
+		return
+	}
+	p.lastReport = time.Now()
+	p.progressMu.Unlock()
+
+	p.logger.FEEDBACK.Printf("Processing images: %d done, %d in progress\n", done, inProgress)
+}
+
+// processingMemory estimates the memory in bytes needed to process i, that
+// is the decoded image plus the processed copy.
+func (i *Image) processingMemory() int64 {
+	if err := i.initConfig(); err != nil {
+		return 0
+	}
+
+	bytesPerPixel := int64(4)
+	switch i.config.ColorModel {
+	case color.RGBA64Model, color.NRGBA64Model, color.Gray16Model:
+		// E.g. 16-bit TIFFs.
+		bytesPerPixel = 8
+	}
+
+	pixels := int64(i.config.Width) * int64(i.config.Height)
+
+	return pixels*bytesPerPixel + pixels*4
+}
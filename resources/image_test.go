@@ -15,12 +15,17 @@ package resources
 
 import (
 	"fmt"
+	"image"
 	"math/rand"
 	"path/filepath"
+	"runtime"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/disintegration/imaging"
+	"github.com/gohugoio/hugo/common/loggers"
 
 	"sync"
 
@@ -37,6 +42,8 @@ func TestParseImageConfig(t *testing.T) {
 		{"10x20 topleft Lanczos", newImageConfig(10, 20, 0, 0, "Lanczos", "topleft")},
 		{"linear left 10x r180", newImageConfig(10, 0, 0, 180, "linear", "left")},
 		{"x20 riGht Cosine q95", newImageConfig(0, 20, 95, 0, "cosine", "right")},
+		{"800x webp q75", withTargetFormat(newImageConfig(800, 0, 75, 0, "", ""), "webp")},
+		{"300x200 PNG", withTargetFormat(newImageConfig(300, 200, 0, 0, "", ""), "png")},
 
 		{"", false},
 		{"foo", false},
@@ -57,6 +64,12 @@ func TestParseImageConfig(t *testing.T) {
 	}
 }
 
+func withTargetFormat(c imageConfig, format string) imageConfig {
+	c.TargetFormat = imageFormats["."+format]
+	c.TargetFormatStr = format
+	return c
+}
+
 func TestImageTransformBasic(t *testing.T) {
 
 	assert := require.New(t)
@@ -247,6 +260,8 @@ func TestDecodeImaging(t *testing.T) {
 		"quality":        42,
 		"resampleFilter": "NearestNeighbor",
 		"anchor":         "topLeft",
+		"workers":        3,
+		"memoryLimit":    512,
 	}
 
 	imaging, err := decodeImaging(m)
@@ -255,6 +270,8 @@ func TestDecodeImaging(t *testing.T) {
 	assert.Equal(42, imaging.Quality)
 	assert.Equal("nearestneighbor", imaging.ResampleFilter)
 	assert.Equal("topleft", imaging.Anchor)
+	assert.Equal(3, imaging.Workers)
+	assert.Equal(512, imaging.MemoryLimit)
 
 	m = map[string]interface{}{}
 
@@ -263,12 +280,19 @@ func TestDecodeImaging(t *testing.T) {
 	assert.Equal(defaultJPEGQuality, imaging.Quality)
 	assert.Equal("box", imaging.ResampleFilter)
 	assert.Equal("smart", imaging.Anchor)
+	assert.Equal(runtime.NumCPU(), imaging.Workers)
+	assert.Equal(defaultImagingMemoryLimit, imaging.MemoryLimit)
 
 	_, err = decodeImaging(map[string]interface{}{
 		"quality": 123,
 	})
 	assert.Error(err)
 
+	_, err = decodeImaging(map[string]interface{}{
+		"workers": -1,
+	})
+	assert.Error(err)
+
 	_, err = decodeImaging(map[string]interface{}{
 		"resampleFilter": "asdf",
 	})
@@ -287,6 +311,42 @@ func TestDecodeImaging(t *testing.T) {
 
 }
 
+func TestImageProcessingLimits(t *testing.T) {
+	assert := require.New(t)
+
+	p := newImageProcessing(&Imaging{Workers: 2, MemoryLimit: 1}, loggers.NewErrorLogger())
+	mb := int64(1024 * 1024)
+
+	release1, err := p.acquire(mb / 2)
+	assert.NoError(err)
+	release2, err := p.acquire(mb / 4)
+	assert.NoError(err)
+
+	// Both the workers and the memory are busy.
+	acquired := make(chan bool)
+	go func() {
+		// More than the limit, must wait for the others to finish.
+		release3, err := p.acquire(2 * mb)
+		assert.NoError(err)
+		release3()
+		acquired <- true
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquired more than the limits")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release1()
+	release2()
+
+	<-acquired
+
+	assert.Equal(uint64(3), p.done)
+	assert.Equal(int64(0), p.inProgress)
+}
+
 func TestImageWithMetadata(t *testing.T) {
 	assert := require.New(t)
 
@@ -327,6 +387,298 @@ func TestImageResize8BitPNG(t *testing.T) {
 
 }
 
+func TestImageTransformFormat(t *testing.T) {
+
+	assert := require.New(t)
+
+	image := fetchSunset(assert)
+	fileCache := image.spec.FileCaches.ImageCache().Fs
+
+	assert.Equal(imaging.JPEG, image.format)
+
+	converted, err := image.Resize("200x png")
+	assert.NoError(err)
+	assert.Equal(imaging.PNG, converted.format)
+	assert.Equal("image/png", converted.MediaType().Type())
+	assert.Equal("/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_200x0_resize_linear_png_2.png", converted.RelPermalink())
+	assert.Equal(200, converted.Width())
+	assert.Equal(125, converted.Height())
+	assertFileCache(assert, fileCache, converted.RelPermalink(), 200, 125)
+
+	// The format is kept in further processing.
+	fitted, err := converted.Fit("100x100")
+	assert.NoError(err)
+	assert.Equal(imaging.PNG, fitted.format)
+	assert.True(strings.HasSuffix(fitted.RelPermalink(), ".png"), fitted.RelPermalink())
+
+	// Check cache
+	convertedAgain, err := image.Resize("200x png")
+	assert.NoError(err)
+	assert.True(converted == convertedAgain)
+
+	// Not the same as the JPEG version.
+	resized, err := image.Resize("200x")
+	assert.NoError(err)
+	assert.Equal(imaging.JPEG, resized.format)
+	assert.Equal("image/jpg", resized.MediaType().Type())
+	assert.Equal("/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_200x0_resize_q68_linear.jpg", resized.RelPermalink())
+
+}
+
+func TestImageCrop(t *testing.T) {
+
+	assert := require.New(t)
+
+	image := fetchSunset(assert)
+	fileCache := image.spec.FileCaches.ImageCache().Fs
+
+	cropped, err := image.Crop("400x300 TopLeft")
+	assert.NoError(err)
+	assert.Equal("/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_400x300_crop_q68_linear_topleft.jpg", cropped.RelPermalink())
+	assert.Equal(400, cropped.Width())
+	assert.Equal(300, cropped.Height())
+	assertFileCache(assert, fileCache, cropped.RelPermalink(), 400, 300)
+
+	// The test config's default anchor is left.
+	cropped, err = image.Crop("400x300")
+	assert.NoError(err)
+	assert.True(strings.HasSuffix(cropped.RelPermalink(), "_400x300_crop_q68_linear_left.jpg"), cropped.RelPermalink())
+
+	// Cannot crop to more than the image.
+	cropped, err = image.Crop("2000x300 Center")
+	assert.NoError(err)
+	assert.Equal(image.Width(), cropped.Width())
+	assert.Equal(300, cropped.Height())
+
+	smart, err := image.Crop("200x100 smart")
+	assert.NoError(err)
+	assert.Equal(200, smart.Width())
+	assert.Equal(100, smart.Height())
+
+	_, err = image.Crop("400x")
+	assert.Error(err)
+}
+
+func TestImageFocalPoint(t *testing.T) {
+
+	assert := require.New(t)
+
+	image := fetchSunset(assert)
+
+	assert.NoError(AssignMetadata([]map[string]interface{}{
+		{
+			"src":        "sunset*",
+			"focalPoint": []interface{}{75, 25},
+		},
+		{
+			"src":        "*",
+			"focalPoint": "10,10",
+		},
+	}, image))
+	assert.Equal(&focalPoint{X: 75, Y: 25}, image.focalPoint)
+
+	filled, err := image.Fill("200x100")
+	assert.NoError(err)
+	assert.Equal("/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_200x100_fill_q68_linear_fp75x25.jpg", filled.RelPermalink())
+	assert.Equal(200, filled.Width())
+	assert.Equal(100, filled.Height())
+	assert.Nil(filled.focalPoint)
+
+	cropped, err := image.Crop("300x200")
+	assert.NoError(err)
+	assert.True(strings.HasSuffix(cropped.RelPermalink(), "_300x200_crop_q68_linear_fp75x25.jpg"), cropped.RelPermalink())
+	assert.Equal(300, cropped.Width())
+	assert.Equal(200, cropped.Height())
+
+	// An anchor in the spec takes precedence.
+	filled, err = image.Fill("200x100 TopLeft")
+	assert.NoError(err)
+	assert.True(strings.HasSuffix(filled.RelPermalink(), "_200x100_fill_q68_linear_topleft.jpg"), filled.RelPermalink())
+
+	// The focal point is kept when scaling.
+	resized, err := image.Resize("300x")
+	assert.NoError(err)
+	assert.Equal(image.focalPoint, resized.focalPoint)
+	filled, err = resized.Fill("100x100")
+	assert.NoError(err)
+	assert.True(strings.Contains(filled.RelPermalink(), "_fp75x25"), filled.RelPermalink())
+}
+
+func TestFocalPoint(t *testing.T) {
+	assert := require.New(t)
+
+	for _, test := range []struct {
+		in     interface{}
+		expect interface{}
+	}{
+		{[]interface{}{30, 60}, focalPoint{30, 60}},
+		{[]int{0, 100}, focalPoint{0, 100}},
+		{"30,60", focalPoint{30, 60}},
+		{"30.5% 60%", focalPoint{30.5, 60}},
+		{"30", false},
+		{"130,60", false},
+		{[]interface{}{"a", 60}, false},
+		{42, false},
+	} {
+		fp, err := parseFocalPoint(test.in)
+		if b, ok := test.expect.(bool); ok && !b {
+			assert.Error(err, fmt.Sprint(test.in))
+			continue
+		}
+		assert.NoError(err)
+		assert.Equal(test.expect, *fp)
+	}
+
+	b := image.Rect(0, 0, 100, 50)
+	assert.Equal(image.Rect(0, 0, 40, 20), focalPoint{0, 0}.rect(b, 40, 20))
+	assert.Equal(image.Rect(60, 30, 100, 50), focalPoint{100, 100}.rect(b, 40, 20))
+	assert.Equal(image.Rect(30, 15, 70, 35), focalPoint{50, 50}.rect(b, 40, 20))
+	assert.Equal(image.Rect(0, 0, 100, 50), focalPoint{50, 50}.rect(b, 200, 200))
+}
+
+func TestImageFilter(t *testing.T) {
+
+	assert := require.New(t)
+
+	image := fetchSunset(assert)
+	fileCache := image.spec.FileCaches.ImageCache().Fs
+
+	blur, err := BlurFilter(6)
+	assert.NoError(err)
+	sepia, err := SepiaFilter(80)
+	assert.NoError(err)
+
+	filtered, err := image.Filter(GrayscaleFilter(), blur)
+	assert.NoError(err)
+	assert.True(image != filtered)
+	assert.Equal(imaging.JPEG, filtered.format)
+	assert.True(strings.Contains(filtered.RelPermalink(), "_filter_"), filtered.RelPermalink())
+	assert.True(strings.HasSuffix(filtered.RelPermalink(), "_q68.jpg"), filtered.RelPermalink())
+	assert.Equal(image.Width(), filtered.Width())
+	assert.Equal(image.Height(), filtered.Height())
+	assertFileCache(assert, fileCache, filtered.RelPermalink(), image.Width(), image.Height())
+
+	// Check cache, filters can also be given in slices.
+	filteredAgain, err := image.Filter([]interface{}{GrayscaleFilter(), blur})
+	assert.NoError(err)
+	assert.True(filtered == filteredAgain)
+
+	// The order matters.
+	reversed, err := image.Filter(blur, GrayscaleFilter())
+	assert.NoError(err)
+	assert.NotEqual(filtered.RelPermalink(), reversed.RelPermalink())
+
+	// Filters can be combined with the other operations.
+	resized, err := image.Resize("200x")
+	assert.NoError(err)
+	resizedAndFiltered, err := resized.Filter(sepia)
+	assert.NoError(err)
+	assert.Equal(200, resizedAndFiltered.Width())
+	assert.Equal(125, resizedAndFiltered.Height())
+
+	logo := fetchImage(assert, "gohugoio.png")
+	watermark, err := OverlayFilter(logo, 20, 20, 0.5)
+	assert.NoError(err)
+	watermarked, err := image.Filter(watermark)
+	assert.NoError(err)
+	assert.Equal(image.Width(), watermarked.Width())
+	assertFileCache(assert, fileCache, watermarked.RelPermalink(), image.Width(), image.Height())
+
+	_, err = image.Filter()
+	assert.Error(err)
+	_, err = image.Filter("blur")
+	assert.Error(err)
+}
+
+func TestImageFilterOptions(t *testing.T) {
+	assert := require.New(t)
+
+	for _, test := range []struct {
+		create func() (ImageFilter, error)
+		key    string
+	}{
+		{func() (ImageFilter, error) { return BlurFilter(1.5) }, "blur_1.5"},
+		{func() (ImageFilter, error) { return SharpenFilter(2) }, "sharpen_2"},
+		{func() (ImageFilter, error) { return BrightnessFilter(-20) }, "brightness_-20"},
+		{func() (ImageFilter, error) { return ContrastFilter(30) }, "contrast_30"},
+		{func() (ImageFilter, error) { return SaturationFilter(200) }, "saturation_200"},
+		{func() (ImageFilter, error) { return GammaFilter(0.8) }, "gamma_0.8"},
+		{func() (ImageFilter, error) { return SepiaFilter(100) }, "sepia_100"},
+		{func() (ImageFilter, error) { return ColorizeFilter(240, 50, 100) }, "colorize_240_50_100"},
+		{func() (ImageFilter, error) { return PixelateFilter(8) }, "pixelate_8"},
+		{func() (ImageFilter, error) { return BlurFilter(0) }, ""},
+		{func() (ImageFilter, error) { return BrightnessFilter(101) }, ""},
+		{func() (ImageFilter, error) { return SaturationFilter(-101) }, ""},
+		{func() (ImageFilter, error) { return GammaFilter(-1) }, ""},
+		{func() (ImageFilter, error) { return ColorizeFilter(400, 50, 100) }, ""},
+		{func() (ImageFilter, error) { return PixelateFilter(0) }, ""},
+		{func() (ImageFilter, error) { return OverlayFilter(nil, 0, 0, 1) }, ""},
+	} {
+		f, err := test.create()
+		if test.key == "" {
+			assert.Error(err)
+			continue
+		}
+		assert.NoError(err)
+		assert.Equal(test.key, f.Key())
+	}
+}
+
+func TestImageExif(t *testing.T) {
+
+	assert := require.New(t)
+
+	image := fetchSunset(assert)
+
+	x, err := image.Exif()
+	assert.NoError(err)
+	assert.NotNil(x)
+	assert.Equal("2017-10-27", x.Date.Format("2006-01-02"))
+	assert.InDelta(36.59744, x.Lat, 0.00001)
+	assert.Equal(5.6, x.Tags["FNumber"])
+	assert.Equal([]interface{}{"Malaga", "Torremolinos"}, x.Tags["Keywords"])
+
+	// Read from the file cache.
+	imageAgain := fetchImageForSpec(image.spec, assert, "sunset.jpg")
+	xAgain, err := imageAgain.Exif()
+	assert.NoError(err)
+	assert.Equal(x.Tags, xAgain.Tags)
+	assert.True(x.Date.Equal(xAgain.Date))
+
+	resized, err := image.Resize("200x")
+	assert.NoError(err)
+	x, err = resized.Exif()
+	assert.NoError(err)
+	assert.Nil(x)
+
+	png := fetchImage(assert, "gohugoio.png")
+	x, err = png.Exif()
+	assert.NoError(err)
+	assert.Nil(x)
+}
+
+func TestImageApplyOrientation(t *testing.T) {
+	assert := require.New(t)
+
+	src := image.NewNRGBA(image.Rect(0, 0, 30, 20))
+
+	for orientation := 1; orientation <= 8; orientation++ {
+		b := applyOrientation(src, orientation).Bounds()
+		if orientation >= 5 {
+			assert.Equal(20, b.Dx())
+			assert.Equal(30, b.Dy())
+		} else {
+			assert.Equal(30, b.Dx())
+			assert.Equal(20, b.Dy())
+		}
+	}
+
+	conf := newImageConfig(300, 200, 0, 0, "linear", "")
+	conf.Action = "resize"
+	conf.Orientation = 6
+	assert.Equal("300x200_resize_linear_o6", conf.key(imaging.JPEG))
+}
+
 func TestImageResizeInSubPath(t *testing.T) {
 
 	assert := require.New(t)
@@ -0,0 +1,43 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build extended
+
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageWebP(t *testing.T) {
+	assert := require.New(t)
+
+	image := fetchImage(assert, "pixel.webp")
+	assert.Equal(webpFormat, image.format)
+
+	resized, err := image.Resize("2x")
+	assert.NoError(err)
+	assert.Equal(webpFormat, resized.format)
+	assert.Equal("image/webp", resized.MediaType().Type())
+	assert.True(strings.HasSuffix(resized.RelPermalink(), ".webp"), resized.RelPermalink())
+	assert.Equal(2, resized.Width())
+
+	converted, err := fetchSunset(assert).Resize("200x webp")
+	assert.NoError(err)
+	assert.Equal(webpFormat, converted.format)
+	assert.Equal("image/webp", converted.MediaType().Type())
+	assert.Equal(200, converted.Width())
+}
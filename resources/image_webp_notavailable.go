@@ -0,0 +1,30 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !extended
+
+package resources
+
+import (
+	"image"
+	"io"
+
+	"github.com/gohugoio/hugo/common/herrors"
+)
+
+// WebP encoding is only available in the extended Hugo version.
+const webpEncodingAvailable = false
+
+func encodeWebP(w io.Writer, img image.Image, quality int) error {
+	return herrors.ErrFeatureNotAvailable
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/gohugoio/hugo/common/maps"
 
 	"github.com/gohugoio/hugo/compare"
+	"github.com/gohugoio/hugo/helpers"
 
 	"github.com/gohugoio/hugo/navigation"
 	"github.com/gohugoio/hugo/related"
@@ -54,6 +55,13 @@ type AuthorProvider interface {
 	Authors() AuthorList
 }
 
+// BacklinksProvider provides the pages linking to a Page.
+type BacklinksProvider interface {
+	// Backlinks returns the pages linking to this page with ref or relref,
+	// and, if enabled, with a Markdown link in their content.
+	Backlinks() Pages
+}
+
 // ChildCareProvider provides accessors to child resources.
 type ChildCareProvider interface {
 	Pages() Pages
@@ -99,6 +107,14 @@ type InSectionPositioner interface {
 	PrevInSection() Page
 }
 
+// TermPositioner provides navigation within the ordered pages of a taxonomy
+// term, e.g. the parts of a tutorial series.
+type TermPositioner interface {
+	// PositionIn returns the position of the page in the given taxonomy term,
+	// or nil if the page is not in that term.
+	PositionIn(taxonomy, term string) (*TermPosition, error)
+}
+
 // InternalDependencies is considered an internal interface.
 type InternalDependencies interface {
 	GetRelatedDocsHandler() *RelatedDocsHandler
@@ -221,6 +237,7 @@ type PageWithoutContent interface {
 
 	// Horisontal navigation
 	InSectionPositioner
+	TermPositioner
 	PageRenderProvider
 	PaginatorProvider
 	Positioner
@@ -232,6 +249,7 @@ type PageWithoutContent interface {
 	// Page lookups/refs
 	GetPageProvider
 	RefProvider
+	BacklinksProvider
 
 	resource.TranslationKeyProvider
 	TranslationsProvider
@@ -294,7 +312,11 @@ type SitesProvider interface {
 
 // TableOfContentsProvider provides the table of contents for a Page.
 type TableOfContentsProvider interface {
+	// TableOfContents returns the table of contents rendered as HTML.
 	TableOfContents() template.HTML
+
+	// TOC returns the table of contents as a tree of headings.
+	TOC() *helpers.TableOfContents
 }
 
 // TranslationsProvider provides access to any translations.
@@ -337,8 +359,24 @@ type TreeProvider interface {
 
 	// Parent returns a section's parent section or a page's section.
 	// To get a section's subsections, see Page's Sections method.
+	// For a term in a hierarchical taxonomy, this is the term above it or,
+	// for the top level terms, the taxonomy's terms page.
 	Parent() Page
 
+	// Ancestors returns the pages above this page in the site tree, starting
+	// with its parent and ending with the home page. Taxonomy terms are placed
+	// below their taxonomy's terms page, which is placed below home.
+	Ancestors() Pages
+
+	// Breadcrumbs returns the Ancestors in reverse order, i.e. from the home
+	// page down, followed by the page itself.
+	Breadcrumbs() Pages
+
+	// Children returns the terms directly below a term in a hierarchical
+	// taxonomy, or the top level terms for the taxonomy's terms page.
+	// This will return an empty list for all other pages.
+	Children() Pages
+
 	// Sections returns this section's subsections, if any.
 	// Note that for non-sections, this method will always return an empty list.
 	Sections() Pages
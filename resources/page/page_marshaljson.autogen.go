@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"github.com/bep/gitmap"
 	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/langs"
 	"github.com/gohugoio/hugo/media"
 	"github.com/gohugoio/hugo/navigation"
@@ -41,6 +42,7 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 	readingTime := p.ReadingTime()
 	length := p.Len()
 	tableOfContents := p.TableOfContents()
+	tOC := p.TOC()
 	rawContent := p.RawContent()
 	mediaType := p.MediaType()
 	resourceType := p.ResourceType()
@@ -97,6 +99,7 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 		ReadingTime              int
 		Len                      int
 		TableOfContents          template.HTML
+		TOC                      *helpers.TableOfContents
 		RawContent               string
 		MediaType                media.Type
 		ResourceType             string
@@ -152,6 +155,7 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 		ReadingTime:              readingTime,
 		Len:                      length,
 		TableOfContents:          tableOfContents,
+		TOC:                      tOC,
 		RawContent:               rawContent,
 		MediaType:                mediaType,
 		ResourceType:             resourceType,
@@ -28,6 +28,7 @@ import (
 	"github.com/gohugoio/hugo/source"
 
 	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/langs"
 	"github.com/gohugoio/hugo/media"
 	"github.com/gohugoio/hugo/related"
@@ -74,6 +75,10 @@ func (p *nopPage) LanguagePrefix() string {
 	return ""
 }
 
+func (p *nopPage) Ancestors() Pages {
+	return nil
+}
+
 func (p *nopPage) AlternativeOutputFormats() OutputFormats {
 	return nil
 }
@@ -303,6 +308,18 @@ func (p *nopPage) Page() Page {
 	return p
 }
 
+func (p *nopPage) Backlinks() Pages {
+	return nil
+}
+
+func (p *nopPage) Breadcrumbs() Pages {
+	return nil
+}
+
+func (p *nopPage) Children() Pages {
+	return nil
+}
+
 func (p *nopPage) Parent() Page {
 	return nil
 }
@@ -323,6 +340,10 @@ func (p *nopPage) PlainWords() []string {
 	return nil
 }
 
+func (p *nopPage) PositionIn(taxonomy, term string) (*TermPosition, error) {
+	return nil, nil
+}
+
 func (p *nopPage) Prev() Page {
 	return nil
 }
@@ -426,6 +447,10 @@ func (p *nopPage) TableOfContents() template.HTML {
 	return ""
 }
 
+func (p *nopPage) TOC() *helpers.TableOfContents {
+	return &helpers.TableOfContents{}
+}
+
 func (p *nopPage) Title() string {
 	return ""
 }
@@ -38,6 +38,10 @@ var (
 type PageGroup struct {
 	Key interface{}
 	Pages
+
+	// The sub groups of this group, e.g. the months of a year, if grouped
+	// further. Pages then holds the pages in all the sub groups.
+	Groups PagesGroup
 }
 
 type mapKeyValues []reflect.Value
@@ -91,6 +95,8 @@ func (p PagesGroup) Reverse() PagesGroup {
 }
 
 var (
+	errNoSuchParam = errors.New("there is no such a param")
+
 	errorType   = reflect.TypeOf((*error)(nil)).Elem()
 	pagePtrType = reflect.TypeOf((*Page)(nil)).Elem()
 	pagesType   = reflect.TypeOf(Pages{})
@@ -190,7 +196,7 @@ func (p Pages) GroupByParam(key string, order ...string) (PagesGroup, error) {
 		}
 	}
 	if !tmp.IsValid() {
-		return nil, errors.New("there is no such a param")
+		return nil, errNoSuchParam
 	}
 
 	for _, e := range p {
@@ -310,6 +316,107 @@ func (p Pages) GroupByParamDate(key string, format string, order ...string) (Pag
 	return p.groupByDateField(sorter, formatter, order...)
 }
 
+// groupBy groups the pages in each group without sub groups further with
+// the given func, returning a copy. Groups left without pages are removed.
+func (psg PagesGroup) groupBy(group func(p Pages) (PagesGroup, error)) (PagesGroup, error) {
+	if len(psg) == 0 {
+		return nil, nil
+	}
+
+	var r PagesGroup
+
+	for _, g := range psg {
+		var (
+			groups PagesGroup
+			err    error
+		)
+
+		if len(g.Groups) > 0 {
+			groups, err = g.Groups.groupBy(group)
+		} else {
+			groups, err = group(g.Pages)
+			if err == errNoSuchParam {
+				groups, err = nil, nil
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(groups) == 0 {
+			continue
+		}
+
+		g.Groups = groups
+		g.Pages = groups.pages()
+		r = append(r, g)
+	}
+
+	return r, nil
+}
+
+// pages returns the pages in all the groups.
+func (psg PagesGroup) pages() Pages {
+	var pages Pages
+	for _, g := range psg {
+		pages = append(pages, g.Pages...)
+	}
+	return pages
+}
+
+// GroupBy groups the pages in each group further by the value in the given
+// field or method name and with the given order, see Pages.GroupBy.
+func (psg PagesGroup) GroupBy(key string, order ...string) (PagesGroup, error) {
+	return psg.groupBy(func(p Pages) (PagesGroup, error) {
+		return p.GroupBy(key, order...)
+	})
+}
+
+// GroupByParam groups the pages in each group further by the given page
+// parameter key's value and with the given order, see Pages.GroupByParam.
+// Pages without the parameter are left out.
+func (psg PagesGroup) GroupByParam(key string, order ...string) (PagesGroup, error) {
+	return psg.groupBy(func(p Pages) (PagesGroup, error) {
+		return p.GroupByParam(key, order...)
+	})
+}
+
+// GroupByDate groups the pages in each group further by the page's Date value
+// in the given format and with the given order, see Pages.GroupByDate.
+func (psg PagesGroup) GroupByDate(format string, order ...string) (PagesGroup, error) {
+	return psg.groupBy(func(p Pages) (PagesGroup, error) {
+		return p.GroupByDate(format, order...)
+	})
+}
+
+// GroupByPublishDate groups the pages in each group further by the page's
+// PublishDate value in the given format and with the given order, see
+// Pages.GroupByPublishDate.
+func (psg PagesGroup) GroupByPublishDate(format string, order ...string) (PagesGroup, error) {
+	return psg.groupBy(func(p Pages) (PagesGroup, error) {
+		return p.GroupByPublishDate(format, order...)
+	})
+}
+
+// GroupByExpiryDate groups the pages in each group further by the page's
+// ExpiryDate value in the given format and with the given order, see
+// Pages.GroupByExpiryDate.
+func (psg PagesGroup) GroupByExpiryDate(format string, order ...string) (PagesGroup, error) {
+	return psg.groupBy(func(p Pages) (PagesGroup, error) {
+		return p.GroupByExpiryDate(format, order...)
+	})
+}
+
+// GroupByParamDate groups the pages in each group further by a date set as a
+// param on the page in the given format and with the given order, see
+// Pages.GroupByParamDate.
+func (psg PagesGroup) GroupByParamDate(key string, format string, order ...string) (PagesGroup, error) {
+	return psg.groupBy(func(p Pages) (PagesGroup, error) {
+		return p.GroupByParamDate(key, format, order...)
+	})
+}
+
 // ProbablyEq wraps comare.ProbablyEqer
 func (p PageGroup) ProbablyEq(other interface{}) bool {
 	otherP, ok := other.(PageGroup)
@@ -321,6 +428,10 @@ func (p PageGroup) ProbablyEq(other interface{}) bool {
 		return false
 	}
 
+	if !p.Groups.ProbablyEq(otherP.Groups) {
+		return false
+	}
+
 	return p.Pages.ProbablyEq(otherP.Pages)
 
 }
@@ -407,3 +407,74 @@ func TestGroupByParamDateWithEmptyPages(t *testing.T) {
 		t.Errorf("PagesGroup isn't empty. It should be %#v, got %#v", nil, groups)
 	}
 }
+
+func TestGroupByNested(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	pages := preparePageGroupTestPages(t)
+
+	groups, err := pages.GroupBy("Section")
+	assert.NoError(err)
+
+	nested, err := groups.GroupByParam("custom_param")
+	assert.NoError(err)
+
+	expect := PagesGroup{
+		{Key: "section1", Pages: Pages{pages[1], pages[0], pages[2]}, Groups: PagesGroup{
+			{Key: "bar", Pages: Pages{pages[1]}},
+			{Key: "foo", Pages: Pages{pages[0], pages[2]}},
+		}},
+		{Key: "section2", Pages: Pages{pages[3], pages[4]}, Groups: PagesGroup{
+			{Key: "bar", Pages: Pages{pages[3]}},
+			{Key: "baz", Pages: Pages{pages[4]}},
+		}},
+	}
+
+	assert.Equal(expect, nested)
+	assert.Nil(groups[0].Groups)
+	assert.Equal(5, nested.Len())
+
+	assert.True(nested.ProbablyEq(expect))
+	assert.False(nested.ProbablyEq(groups))
+
+	// Three levels.
+	nested, err = nested.GroupBy("Weight", "desc")
+	assert.NoError(err)
+	assert.Len(nested[0].Groups[1].Groups, 2)
+	assert.Equal(3, nested[0].Groups[1].Groups[0].Key)
+	assert.Equal(Pages{pages[0]}, nested[0].Groups[1].Groups[0].Pages)
+
+	// Groups without any page with the param are removed.
+	delete(pages[3].Params(), "custom_param")
+	delete(pages[4].Params(), "custom_param")
+	nested, err = groups.GroupByParam("custom_param")
+	assert.NoError(err)
+	assert.Len(nested, 1)
+	assert.Equal("section1", nested[0].Key)
+}
+
+func TestGroupByDateNested(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	pages := preparePageGroupTestPages(t)
+
+	years, err := pages.GroupByDate("2006")
+	assert.NoError(err)
+
+	months, err := years.GroupByDate("January")
+	assert.NoError(err)
+
+	assert.Len(months, 1)
+	assert.Equal("2012", months[0].Key)
+	assert.Len(months[0].Pages, 5)
+
+	var keys []string
+	var counts []int
+	for _, g := range months[0].Groups {
+		keys = append(keys, g.Key.(string))
+		counts = append(counts, len(g.Pages))
+	}
+
+	assert.Equal([]string{"April", "March", "January"}, keys)
+	assert.Equal([]int{3, 1, 1}, counts)
+}
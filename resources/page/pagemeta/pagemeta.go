@@ -13,9 +13,50 @@
 
 package pagemeta
 
+import (
+	"github.com/mitchellh/mapstructure"
+)
+
 type URLPath struct {
 	URL       string
 	Permalink string
 	Slug      string
 	Section   string
 }
+
+// BuildConfig holds the configuration options for how a page gets built,
+// set with the _build key in front matter.
+type BuildConfig struct {
+	// Whether to add it to any of the page collections.
+	// Note that the page can always be found with .Site.GetPage.
+	List bool
+
+	// Whether to render it.
+	Render bool
+
+	// Whether to publish its resources. These will still be published on
+	// demand, but disabling this can be useful if the originals (e.g. images)
+	// are never used.
+	PublishResources bool
+}
+
+// DefaultBuildConfig is the build configuration for a page without any
+// _build options set.
+var DefaultBuildConfig = BuildConfig{
+	List:             true,
+	Render:           true,
+	PublishResources: true,
+}
+
+// DecodeBuildConfig decodes the _build front matter options in m, with the
+// options not set in m taken from DefaultBuildConfig.
+func DecodeBuildConfig(m interface{}) (BuildConfig, error) {
+	b := DefaultBuildConfig
+	if m == nil {
+		return b, nil
+	}
+
+	err := mapstructure.WeakDecode(m, &b)
+
+	return b, err
+}
@@ -0,0 +1,38 @@
+// Copyright 2019 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagemeta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBuildConfig(t *testing.T) {
+	assert := require.New(t)
+
+	b, err := DecodeBuildConfig(nil)
+	assert.NoError(err)
+	assert.Equal(DefaultBuildConfig, b)
+
+	b, err = DecodeBuildConfig(map[string]interface{}{
+		"render":           false,
+		"publishresources": "false",
+	})
+	assert.NoError(err)
+	assert.Equal(BuildConfig{List: true, Render: false, PublishResources: false}, b)
+
+	_, err = DecodeBuildConfig("foo")
+	assert.Error(err)
+}
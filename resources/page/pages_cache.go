@@ -68,16 +68,9 @@ func (c *pageCache) get(key string, apply func(p Pages), pageLists ...Pages) (Pa
 }
 
 func (c *pageCache) getP(key string, apply func(p *Pages), pageLists ...Pages) (Pages, bool) {
-	c.RLock()
-	if cached, ok := c.m[key]; ok {
-		for _, entry := range cached {
-			if entry.matches(pageLists) {
-				c.RUnlock()
-				return entry.out, true
-			}
-		}
+	if cached, found := c.lookup(key, pageLists...); found {
+		return cached, true
 	}
-	c.RUnlock()
 
 	c.Lock()
 	defer c.Unlock()
@@ -109,6 +102,23 @@ func (c *pageCache) getP(key string, apply func(p *Pages), pageLists ...Pages) (
 
 }
 
+// lookup gets a Pages slice from the cache matching the given key and all
+// the provided Pages slices, if any.
+func (c *pageCache) lookup(key string, pageLists ...Pages) (Pages, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if cached, ok := c.m[key]; ok {
+		for _, entry := range cached {
+			if entry.matches(pageLists) {
+				return entry.out, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
 // pagesEqual returns whether p1 and p2 are equal.
 func pagesEqual(p1, p2 Pages) bool {
 	if p1 == nil && p2 == nil {
@@ -71,6 +71,24 @@ func TestPageCache(t *testing.T) {
 	wg.Wait()
 }
 
+func TestPageCacheLookup(t *testing.T) {
+	t.Parallel()
+	c := newPageCache()
+	pages := createSortTestPages(3)
+
+	p, found := c.lookup("k1", pages)
+	assert.False(t, found)
+	assert.Nil(t, p)
+
+	p1, _ := c.get("k1", nil, pages)
+	p, found = c.lookup("k1", pages)
+	assert.True(t, found)
+	assert.True(t, pagesEqual(p1, p))
+
+	_, found = c.lookup("k2", pages)
+	assert.False(t, found)
+}
+
 func BenchmarkPageCache(b *testing.B) {
 	cache := newPageCache()
 	pages := make(Pages, 30)
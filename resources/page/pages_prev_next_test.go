@@ -81,3 +81,31 @@ func TestWeightedPagesNext(t *testing.T) {
 	assert.Equal(t, w.Next(w[1].Page), w[2].Page)
 	assert.Equal(t, w.Next(w[4].Page), w[0].Page)
 }
+
+func TestNewTermPosition(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	w := prepareWeightedPagesPrevNext(t)
+
+	first := NewTermPosition(w, 0)
+	assert.Equal(0, first.Index)
+	assert.Equal(1, first.Number)
+	assert.Equal(5, first.Total)
+	assert.Nil(first.Prev)
+	assert.Equal(w[1].Page, first.Next)
+	assert.True(first.IsFirst())
+	assert.False(first.IsLast())
+
+	middle := NewTermPosition(w, 2)
+	assert.Equal(3, middle.Number)
+	assert.Equal(w[1].Page, middle.Prev)
+	assert.Equal(w[3].Page, middle.Next)
+	assert.False(middle.IsFirst())
+	assert.False(middle.IsLast())
+
+	last := NewTermPosition(w, 4)
+	assert.Equal(5, last.Number)
+	assert.Equal(w[3].Page, last.Prev)
+	assert.Nil(last.Next)
+	assert.True(last.IsLast())
+}
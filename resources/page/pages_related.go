@@ -147,16 +147,20 @@ type cachedPostingList struct {
 type RelatedDocsHandler struct {
 	cfg related.Config
 
+	// Caches the terms of the page text for the TF-IDF indices across
+	// rebuilds.
+	termCache *related.TermCache
+
 	postingLists []*cachedPostingList
 	mu           sync.RWMutex
 }
 
 func NewRelatedDocsHandler(cfg related.Config) *RelatedDocsHandler {
-	return &RelatedDocsHandler{cfg: cfg}
+	return &RelatedDocsHandler{cfg: cfg, termCache: related.NewTermCache()}
 }
 
 func (s *RelatedDocsHandler) Clone() *RelatedDocsHandler {
-	return NewRelatedDocsHandler(s.cfg)
+	return &RelatedDocsHandler{cfg: s.cfg, termCache: s.termCache.Next()}
 }
 
 // This assumes that a lock has been acquired.
@@ -178,14 +182,10 @@ func (s *RelatedDocsHandler) getOrCreateIndex(p Pages) (*related.InvertedIndex,
 	}
 	s.mu.RUnlock()
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if cachedIndex := s.getIndex(p); cachedIndex != nil {
-		return cachedIndex, nil
-	}
-
-	searchIndex := related.NewInvertedIndex(s.cfg)
+	// Build the index before taking the lock. The keywords of a tfidf index
+	// are the words in the page content, and rendering the content may
+	// search for related pages, e.g. in a shortcode.
+	searchIndex := related.NewInvertedIndexWithTermCache(s.cfg, s.termCache)
 
 	for _, page := range p {
 		if err := searchIndex.Add(page); err != nil {
@@ -193,6 +193,13 @@ func (s *RelatedDocsHandler) getOrCreateIndex(p Pages) (*related.InvertedIndex,
 		}
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cachedIndex := s.getIndex(p); cachedIndex != nil {
+		return cachedIndex, nil
+	}
+
 	s.postingLists = append(s.postingLists, &cachedPostingList{p: p, postingList: searchIndex})
 
 	return searchIndex, nil
@@ -14,9 +14,13 @@
 package page
 
 import (
+	"reflect"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/gohugoio/hugo/resources/resource"
+	"github.com/pkg/errors"
 
 	"github.com/spf13/cast"
 )
@@ -323,15 +327,6 @@ func (p Pages) ByParam(paramsKey interface{}) Pages {
 			return true
 		}
 
-		isNumeric := func(v interface{}) bool {
-			switch v.(type) {
-			case uint8, uint16, uint32, uint64, int, int8, int16, int32, int64, float32, float64:
-				return true
-			default:
-				return false
-			}
-		}
-
 		if isNumeric(v1) && isNumeric(v2) {
 			return cast.ToFloat64(v1) < cast.ToFloat64(v2)
 		}
@@ -346,3 +341,206 @@ func (p Pages) ByParam(paramsKey interface{}) Pages {
 
 	return pages
 }
+
+// SortBy sorts the pages by the given keys and returns a copy. Each key may
+// be followed by a sort direction, "asc" (default) or "desc", e.g.
+//
+//	{{ .Pages.SortBy "Weight" "Params.series_order" "Date" "desc" }}
+//
+// A key is the name of a Page method without arguments, e.g. "Date" or
+// "Title", or a dot chained path to a front matter param, e.g.
+// "Params.series.order". Pages without a value for a key are sorted last.
+// The sort is stable.
+//
+// Adjacent invocations on the same receiver with the same keys will return a cached result.
+//
+// This may safely be executed  in parallel.
+func (p Pages) SortBy(keys ...interface{}) (Pages, error) {
+	sortKeys, err := parsePagesSortKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	key := "pageSort.SortBy"
+	for _, k := range sortKeys {
+		key += "." + k.String()
+	}
+
+	if pages, found := spc.lookup(key, p); found {
+		return pages, nil
+	}
+
+	// The values must be computed outside of the page cache lock, as a key
+	// may be e.g. WordCount, which renders content that may in turn sort
+	// pages in a shortcode.
+	values := make(map[Page][]interface{}, len(p))
+	for _, pp := range p {
+		pv := make([]interface{}, len(sortKeys))
+		for i, k := range sortKeys {
+			pv[i] = k.value(pp)
+		}
+		values[pp] = pv
+	}
+
+	sortFunc := func(pages Pages) {
+		less := func(p1, p2 Page) bool {
+			v1, v2 := values[p1], values[p2]
+			for i, k := range sortKeys {
+				c := compareSortValues(v1[i], v2[i])
+				if c == 0 {
+					continue
+				}
+				if v1[i] == nil || v2[i] == nil {
+					// Missing values last in both directions.
+					return v2[i] == nil
+				}
+				return (c < 0) != k.desc
+			}
+			return false
+		}
+
+		pageBy(less).Sort(pages)
+	}
+
+	pages, _ := spc.get(key, sortFunc, p)
+
+	return pages, nil
+}
+
+// pagesSortKey is a key to sort pages by, see SortBy.
+type pagesSortKey struct {
+	// The name of a Page method, empty for params.
+	method string
+
+	// The path to a front matter param, lower case.
+	params []string
+
+	desc bool
+}
+
+func (k pagesSortKey) String() string {
+	s := k.method
+	if s == "" {
+		s = "params." + strings.Join(k.params, ".")
+	}
+	if k.desc {
+		s += ":desc"
+	}
+	return s
+}
+
+// value returns the value of the key for the given page, or nil if not set.
+func (k pagesSortKey) value(p Page) interface{} {
+	if k.method == "" {
+		v, _ := resource.Param(p, nil, strings.Join(k.params, "."))
+		return v
+	}
+
+	m := reflect.ValueOf(p).MethodByName(k.method)
+	if !m.IsValid() {
+		return nil
+	}
+
+	res := m.Call(nil)
+	if len(res) == 2 && !res[1].IsNil() {
+		return nil
+	}
+
+	return res[0].Interface()
+}
+
+var pageInterfaceType = reflect.TypeOf((*Page)(nil)).Elem()
+
+// parsePagesSortKeys parses the keys given to SortBy.
+func parsePagesSortKeys(args []interface{}) ([]pagesSortKey, error) {
+	var keys []pagesSortKey
+
+	for _, arg := range args {
+		s, err := cast.ToStringE(arg)
+		if err != nil {
+			return nil, errors.New("sort keys must be strings")
+		}
+
+		if dir := strings.ToLower(s); dir == "asc" || dir == "desc" {
+			if len(keys) == 0 {
+				return nil, errors.Errorf("sort direction %q must follow a key", s)
+			}
+			keys[len(keys)-1].desc = dir == "desc"
+			continue
+		}
+
+		path := strings.Split(strings.Trim(s, "."), ".")
+
+		if strings.EqualFold(path[0], "params") {
+			if len(path) == 1 {
+				return nil, errors.New("a sort key on params must have a param name, e.g. Params.weight")
+			}
+			keys = append(keys, pagesSortKey{params: strings.Split(strings.ToLower(strings.Join(path[1:], ".")), ".")})
+			continue
+		}
+
+		if len(path) > 1 {
+			return nil, errors.Errorf("invalid sort key %q", s)
+		}
+
+		m, found := pageInterfaceType.MethodByName(s)
+		if !found || m.Type.NumIn() != 0 || m.Type.NumOut() == 0 || m.Type.NumOut() > 2 {
+			return nil, errors.Errorf("%q is not a Page method that can be sorted by", s)
+		}
+
+		keys = append(keys, pagesSortKey{method: s})
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no sort keys given")
+	}
+
+	return keys, nil
+}
+
+// compareSortValues compares the two values by number, time or string,
+// returning -1, 0 or 1. A nil value is greater than any other value.
+func compareSortValues(v1, v2 interface{}) int {
+	switch {
+	case v1 == nil && v2 == nil:
+		return 0
+	case v1 == nil:
+		return 1
+	case v2 == nil:
+		return -1
+	}
+
+	if t1, ok := v1.(time.Time); ok {
+		if t2, ok := v2.(time.Time); ok {
+			switch {
+			case t1.Before(t2):
+				return -1
+			case t1.After(t2):
+				return 1
+			}
+			return 0
+		}
+	}
+
+	if isNumeric(v1) && isNumeric(v2) {
+		f1, f2 := cast.ToFloat64(v1), cast.ToFloat64(v2)
+		switch {
+		case f1 < f2:
+			return -1
+		case f1 > f2:
+			return 1
+		}
+		return 0
+	}
+
+	return strings.Compare(cast.ToString(v1), cast.ToString(v2))
+}
+
+func isNumeric(v interface{}) bool {
+	switch v.(type) {
+	case uint8, uint16, uint32, uint64, uint, int, int8, int16, int32, int64, float32, float64:
+		return true
+	default:
+		return false
+	}
+}
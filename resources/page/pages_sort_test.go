@@ -217,6 +217,54 @@ func TestPageSortByParamNumeric(t *testing.T) {
 	assert.Equal(t, unsetValue, unsetSortedValue)
 }
 
+func TestPageSortBy(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	pages := createSortTestPages(6)
+	for i, p := range pages {
+		if i == 5 {
+			continue
+		}
+		p.(*testPage).params["series"] = map[string]interface{}{"order": i % 3}
+	}
+
+	fuzzyWordCounts := func(pages Pages) []int {
+		var counts []int
+		for _, p := range pages {
+			counts = append(counts, p.FuzzyWordCount())
+		}
+		return counts
+	}
+
+	sorted, err := pages.SortBy("Weight", "Params.series.order", "desc")
+	assert.NoError(err)
+	assert.Equal([]int{1, 3, 5, 2, 4, 0}, fuzzyWordCounts(sorted))
+
+	sorted, err = pages.SortBy("Weight", "desc", "FuzzyWordCount", "desc")
+	assert.NoError(err)
+	assert.Equal([]int{4, 2, 0, 5, 3, 1}, fuzzyWordCounts(sorted))
+
+	cached, err := pages.SortBy("Weight", "desc", "FuzzyWordCount", "desc")
+	assert.NoError(err)
+	assert.True(pagesEqual(sorted, cached))
+
+	// The original is not sorted.
+	assert.Equal([]int{0, 1, 2, 3, 4, 5}, fuzzyWordCounts(pages))
+
+	for _, keys := range [][]interface{}{
+		{},
+		{"desc", "Weight"},
+		{"Params"},
+		{"NotAMethod"},
+		{"Param"},
+		{"Weight", 32},
+	} {
+		_, err := pages.SortBy(keys...)
+		assert.Error(err, fmt.Sprint(keys))
+	}
+}
+
 func BenchmarkSortByWeightAndReverse(b *testing.B) {
 	p := createSortTestPages(300)
 
@@ -19,6 +19,7 @@ import (
 	"html/template"
 	"math"
 	"reflect"
+	"strings"
 
 	"github.com/gohugoio/hugo/config"
 
@@ -101,6 +102,21 @@ func (p *Pager) PageGroups() PagesGroup {
 	return paginatorEmptyPageGroups
 }
 
+// Items returns the elements on this page. This is the same as Pages or
+// PageGroups when paginating pages, else a slice of the paginated elements,
+// e.g. data file rows, taxonomy terms or resources.
+func (p *Pager) Items() interface{} {
+	if len(p.paginatedElements) == 0 {
+		return nil
+	}
+
+	if items, ok := p.element().(paginatedItems); ok {
+		return items.v.Interface()
+	}
+
+	return p.element()
+}
+
 func (p *Pager) element() paginatedElement {
 	if len(p.paginatedElements) == 0 {
 		return paginatorEmptyPages
@@ -206,11 +222,31 @@ func splitPages(pages Pages, size int) []paginatedElement {
 	return split
 }
 
+// paginatedItems is a page of elements from a slice of any type.
+type paginatedItems struct {
+	v reflect.Value
+}
+
+func (p paginatedItems) Len() int {
+	return p.v.Len()
+}
+
+func splitItems(items reflect.Value, size int) []paginatedElement {
+	var split []paginatedElement
+	for low, j := 0, items.Len(); low < j; low += size {
+		high := int(math.Min(float64(low+size), float64(j)))
+		split = append(split, paginatedItems{v: items.Slice(low, high)})
+	}
+
+	return split
+}
+
 func splitPageGroups(pageGroups PagesGroup, size int) []paginatedElement {
 
 	type keyPage struct {
-		key  interface{}
-		page Page
+		// The groups the page belongs to, from the top level down.
+		groups []*PageGroup
+		page   Page
 	}
 
 	var (
@@ -218,31 +254,54 @@ func splitPageGroups(pageGroups PagesGroup, size int) []paginatedElement {
 		flattened []keyPage
 	)
 
-	for _, g := range pageGroups {
-		for _, p := range g.Pages {
-			flattened = append(flattened, keyPage{g.Key, p})
+	var flatten func(groups PagesGroup, parents []*PageGroup)
+	flatten = func(groups PagesGroup, parents []*PageGroup) {
+		for i := range groups {
+			g := &groups[i]
+			path := append(parents[:len(parents):len(parents)], g)
+			if len(g.Groups) > 0 {
+				flatten(g.Groups, path)
+				continue
+			}
+			for _, p := range g.Pages {
+				flattened = append(flattened, keyPage{path, p})
+			}
 		}
 	}
 
+	flatten(pageGroups, nil)
+
 	numPages := len(flattened)
 
 	for low, j := 0, numPages; low < j; low += size {
 		high := int(math.Min(float64(low+size), float64(numPages)))
 
 		var (
-			pg         PagesGroup
-			key        interface{}
-			groupIndex = -1
+			pg      PagesGroup
+			current []*PageGroup
 		)
 
 		for k := low; k < high; k++ {
 			kp := flattened[k]
-			if key == nil || key != kp.key {
-				key = kp.key
-				pg = append(pg, PageGroup{Key: key})
-				groupIndex++
+
+			// Find the first level where the page is in another group
+			// than the previous page.
+			level := 0
+			for level < len(current) && level < len(kp.groups) && current[level] == kp.groups[level] {
+				level++
+			}
+
+			groups := &pg
+			for i, g := range kp.groups {
+				if i >= level {
+					*groups = append(*groups, PageGroup{Key: g.Key})
+				}
+				last := &(*groups)[len(*groups)-1]
+				last.Pages = append(last.Pages, kp.page)
+				groups = &last.Groups
 			}
-			pg[groupIndex].Pages = append(pg[groupIndex].Pages, kp.page)
+
+			current = kp.groups
 		}
 		split = append(split, pg)
 	}
@@ -250,8 +309,59 @@ func splitPageGroups(pageGroups PagesGroup, size int) []paginatedElement {
 	return split
 }
 
-func ResolvePagerSize(cfg config.Provider, options ...interface{}) (int, error) {
+// PaginatorOptions holds the options for a paginator.
+type PaginatorOptions struct {
+	// The number of elements per pager.
+	PagerSize int
+
+	// The name of a named paginator, which is also used in its URLs, e.g.
+	// /comments/2/ for "comments". Empty for the page's main paginator.
+	Name string
+}
+
+// ResolvePaginatorOptions resolves the paginator options given to Paginate,
+// which is either the pager size or a map with the keys "name" and
+// "pagerSize". See ResolvePagerSize for the default pager size.
+func ResolvePaginatorOptions(cfg config.Provider, params map[string]interface{}, options ...interface{}) (PaginatorOptions, error) {
+	var opts PaginatorOptions
+
+	if len(options) == 1 {
+		if m, ok := options[0].(map[string]interface{}); ok {
+			options = nil
+			for k, v := range m {
+				switch strings.ToLower(k) {
+				case "name":
+					opts.Name = cast.ToString(v)
+				case "pagersize":
+					options = []interface{}{v}
+				default:
+					return opts, fmt.Errorf("unknown paginator option %q", k)
+				}
+			}
+		}
+	}
+
+	size, err := ResolvePagerSize(cfg, params, options...)
+	if err != nil {
+		return opts, err
+	}
+	opts.PagerSize = size
+
+	return opts, nil
+}
+
+// ResolvePagerSize resolves the pager size from the given options. The
+// default is the "paginate" value in the page's params, e.g. from its or
+// its section's front matter, if set, else the "paginate" site setting.
+func ResolvePagerSize(cfg config.Provider, params map[string]interface{}, options ...interface{}) (int, error) {
 	if len(options) == 0 {
+		if v, found := params["paginate"]; found {
+			pas, err := cast.ToIntE(v)
+			if err != nil || pas <= 0 {
+				return -1, errors.New("'paginate' in front matter must be a positive integer")
+			}
+			return pas, nil
+		}
 		return cfg.GetInt("paginate"), nil
 	}
 
@@ -268,14 +378,24 @@ func ResolvePagerSize(cfg config.Provider, options ...interface{}) (int, error)
 	return pas, nil
 }
 
+// Paginate creates a paginator for seq, which may be Pages, PagesGroup or a
+// slice of any other type.
 func Paginate(td TargetPathDescriptor, seq interface{}, pagerSize int) (*Paginator, error) {
+	return paginate(newPaginationURLFactory(td), seq, pagerSize)
+}
+
+// PaginateNamed is like Paginate, but for a named paginator with its own path
+// segment in the URLs, e.g. /comments/2/ for the path "comments".
+func PaginateNamed(td TargetPathDescriptor, paginatePath string, seq interface{}, pagerSize int) (*Paginator, error) {
+	return paginate(newPaginationURLFactoryForPath(td, paginatePath), seq, pagerSize)
+}
+
+func paginate(urlFactory paginationURLFactory, seq interface{}, pagerSize int) (*Paginator, error) {
 
 	if pagerSize <= 0 {
 		return nil, errors.New("'paginate' configuration setting must be positive to paginate")
 	}
 
-	urlFactory := newPaginationURLFactory(td)
-
 	var paginator *Paginator
 
 	groups, err := ToPagesGroup(seq)
@@ -287,7 +407,11 @@ func Paginate(td TargetPathDescriptor, seq interface{}, pagerSize int) (*Paginat
 	} else {
 		pages, err := ToPages(seq)
 		if err != nil {
-			return nil, err
+			items := reflect.ValueOf(seq)
+			if items.Kind() != reflect.Slice {
+				return nil, err
+			}
+			return newPaginator(splitItems(items, pagerSize), items.Len(), pagerSize, urlFactory)
 		}
 		paginator, _ = newPaginatorFromPages(pages, pagerSize, urlFactory)
 	}
@@ -389,12 +513,16 @@ func newPaginator(elements []paginatedElement, total, size int, urlFactory pagin
 }
 
 func newPaginationURLFactory(d TargetPathDescriptor) paginationURLFactory {
+	return newPaginationURLFactoryForPath(d, d.PathSpec.PaginatePath)
+}
+
+func newPaginationURLFactoryForPath(d TargetPathDescriptor, paginatePath string) paginationURLFactory {
 
 	return func(pageNumber int) string {
 		pathDescriptor := d
 		var rel string
 		if pageNumber > 1 {
-			rel = fmt.Sprintf("/%s/%d/", d.PathSpec.PaginatePath, pageNumber)
+			rel = fmt.Sprintf("/%s/%d/", paginatePath, pageNumber)
 			pathDescriptor.Addends = rel
 		}
 
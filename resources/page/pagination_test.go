@@ -16,6 +16,7 @@ package page
 import (
 	"fmt"
 	"html/template"
+	"reflect"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -40,6 +41,80 @@ func TestSplitPages(t *testing.T) {
 
 }
 
+func TestSplitItems(t *testing.T) {
+	t.Parallel()
+
+	items := make([]map[string]interface{}, 11)
+	for i := range items {
+		items[i] = map[string]interface{}{"n": i}
+	}
+
+	chunks := splitItems(reflect.ValueOf(items), 5)
+	require.Equal(t, 3, len(chunks))
+	require.Equal(t, 5, chunks[0].Len())
+	require.Equal(t, 1, chunks[2].Len())
+
+	last := chunks[2].(paginatedItems).v.Interface().([]map[string]interface{})
+	require.Equal(t, 10, last[0]["n"])
+}
+
+func TestPaginateItems(t *testing.T) {
+	t.Parallel()
+	urlFactory := func(page int) string {
+		return fmt.Sprintf("page/%d/", page)
+	}
+
+	paginator, err := paginate(urlFactory, []string{"a", "b", "c", "d", "e"}, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, paginator.TotalPages())
+	require.Equal(t, 5, paginator.TotalNumberOfElements())
+
+	second := paginator.Pagers()[1]
+	require.Equal(t, []string{"c", "d"}, second.Items())
+	require.Equal(t, 2, second.NumberOfElements())
+	require.Equal(t, paginatorEmptyPages, second.Pages())
+
+	pages := createTestPages(3)
+	paginator, err = paginate(urlFactory, pages, 2)
+	require.NoError(t, err)
+	require.Equal(t, pages[:2], paginator.Pagers()[0].Items())
+
+	_, err = paginate(urlFactory, "abc", 2)
+	require.Error(t, err)
+}
+
+func TestResolvePaginatorOptions(t *testing.T) {
+	t.Parallel()
+	cfg := viper.New()
+	cfg.Set("paginate", 10)
+
+	opts, err := ResolvePaginatorOptions(cfg, nil)
+	require.NoError(t, err)
+	require.Equal(t, PaginatorOptions{PagerSize: 10}, opts)
+
+	opts, err = ResolvePaginatorOptions(cfg, map[string]interface{}{"paginate": 3})
+	require.NoError(t, err)
+	require.Equal(t, PaginatorOptions{PagerSize: 3}, opts)
+
+	opts, err = ResolvePaginatorOptions(cfg, nil, 5)
+	require.NoError(t, err)
+	require.Equal(t, PaginatorOptions{PagerSize: 5}, opts)
+
+	opts, err = ResolvePaginatorOptions(cfg, map[string]interface{}{"paginate": 3}, map[string]interface{}{"name": "comments", "pagerSize": 4})
+	require.NoError(t, err)
+	require.Equal(t, PaginatorOptions{PagerSize: 4, Name: "comments"}, opts)
+
+	opts, err = ResolvePaginatorOptions(cfg, map[string]interface{}{"paginate": 3}, map[string]interface{}{"name": "comments"})
+	require.NoError(t, err)
+	require.Equal(t, PaginatorOptions{PagerSize: 3, Name: "comments"}, opts)
+
+	_, err = ResolvePaginatorOptions(cfg, nil, map[string]interface{}{"foo": "bar"})
+	require.Error(t, err)
+
+	_, err = ResolvePaginatorOptions(cfg, map[string]interface{}{"paginate": -1})
+	require.Error(t, err)
+}
+
 func TestSplitPageGroups(t *testing.T) {
 	t.Parallel()
 	pages := createTestPages(21)
@@ -80,6 +155,50 @@ func TestSplitPageGroups(t *testing.T) {
 
 }
 
+func TestSplitNestedPageGroups(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	pages := createTestPages(5)
+	p0, p1, p2, p3, p4 := pages[0], pages[1], pages[2], pages[3], pages[4]
+
+	groups := PagesGroup{
+		{Key: "a", Pages: Pages{p0, p1, p2}, Groups: PagesGroup{
+			{Key: "x", Pages: Pages{p0}},
+			{Key: "y", Pages: Pages{p1, p2}},
+		}},
+		{Key: "b", Pages: Pages{p3, p4}, Groups: PagesGroup{
+			{Key: "x", Pages: Pages{p3}},
+			{Key: "z", Pages: Pages{p4}},
+		}},
+	}
+
+	chunks := splitPageGroups(groups, 2)
+	assert.Equal(3, len(chunks))
+
+	assert.Equal(PagesGroup{
+		{Key: "a", Pages: Pages{p0, p1}, Groups: PagesGroup{
+			{Key: "x", Pages: Pages{p0}},
+			{Key: "y", Pages: Pages{p1}},
+		}},
+	}, chunks[0])
+
+	assert.Equal(PagesGroup{
+		{Key: "a", Pages: Pages{p2}, Groups: PagesGroup{
+			{Key: "y", Pages: Pages{p2}},
+		}},
+		{Key: "b", Pages: Pages{p3}, Groups: PagesGroup{
+			{Key: "x", Pages: Pages{p3}},
+		}},
+	}, chunks[1])
+
+	assert.Equal(PagesGroup{
+		{Key: "b", Pages: Pages{p4}, Groups: PagesGroup{
+			{Key: "z", Pages: Pages{p4}},
+		}},
+	}, chunks[2])
+}
+
 func TestPager(t *testing.T) {
 	t.Parallel()
 	pages := createTestPages(21)
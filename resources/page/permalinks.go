@@ -19,20 +19,23 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/cast"
 
 	"github.com/gohugoio/hugo/helpers"
 )
 
-// PermalinkExpander holds permalin mappings per section.
+// PermalinkExpander holds permalink mappings per page kind and section.
 type PermalinkExpander struct {
 	// knownPermalinkAttributes maps :tags in a permalink specification to a
 	// function which, given a page and the tag, returns the resulting string
 	// to be used to replace that tag.
 	knownPermalinkAttributes map[string]pageToPermaAttribute
 
-	expanders map[string]func(Page) (string, error)
+	// The expanders per page kind and section.
+	expanders map[string]map[string]func(Page) (string, error)
 
 	ps *helpers.PathSpec
 }
@@ -44,39 +47,127 @@ func NewPermalinkExpander(ps *helpers.PathSpec) (PermalinkExpander, error) {
 	p := PermalinkExpander{ps: ps}
 
 	p.knownPermalinkAttributes = map[string]pageToPermaAttribute{
-		"year":        p.pageToPermalinkDate,
-		"month":       p.pageToPermalinkDate,
-		"monthname":   p.pageToPermalinkDate,
-		"day":         p.pageToPermalinkDate,
-		"weekday":     p.pageToPermalinkDate,
-		"weekdayname": p.pageToPermalinkDate,
-		"yearday":     p.pageToPermalinkDate,
-		"section":     p.pageToPermalinkSection,
-		"sections":    p.pageToPermalinkSections,
-		"title":       p.pageToPermalinkTitle,
-		"slug":        p.pageToPermalinkSlugElseTitle,
-		"filename":    p.pageToPermalinkFilename,
-	}
-
-	patterns := ps.Cfg.GetStringMapString("permalinks")
-	if patterns == nil {
-		return p, nil
-	}
-
-	e, err := p.parse(patterns)
+		"year":                  p.pageToPermalinkDate,
+		"month":                 p.pageToPermalinkDate,
+		"monthname":             p.pageToPermalinkDate,
+		"day":                   p.pageToPermalinkDate,
+		"weekday":               p.pageToPermalinkDate,
+		"weekdayname":           p.pageToPermalinkDate,
+		"yearday":               p.pageToPermalinkDate,
+		"section":               p.pageToPermalinkSection,
+		"sections":              p.pageToPermalinkSections,
+		"title":                 p.pageToPermalinkTitle,
+		"slug":                  p.pageToPermalinkSlugElseTitle,
+		"filename":              p.pageToPermalinkFilename,
+		"contentbasename":       p.pageToPermalinkContentBaseName,
+		"slugorcontentbasename": p.pageToPermalinkSlugElseContentBaseName,
+		"lang":                  p.pageToPermalinkLang,
+	}
+
+	patterns, err := decodePermalinksConfig(ps.Cfg.Get("permalinks"))
 	if err != nil {
 		return p, err
 	}
 
-	p.expanders = e
+	p.expanders = make(map[string]map[string]func(Page) (string, error))
+
+	for kind, kindPatterns := range patterns {
+		e, err := p.parse(kindPatterns)
+		if err != nil {
+			return p, err
+		}
+		p.expanders[kind] = e
+	}
 
 	return p, nil
 }
 
-// Expand expands the path in p according to the rules defined for the given key.
+// permalinksKinds maps the lower case page kinds to the kinds that can be
+// configured in permalinks.
+var permalinksKinds = map[string]string{
+	KindPage:                          KindPage,
+	KindSection:                       KindSection,
+	KindTaxonomy:                      KindTaxonomy,
+	strings.ToLower(KindTaxonomyTerm): KindTaxonomyTerm,
+}
+
+// decodePermalinksConfig decodes the permalinks configuration into patterns
+// per page kind and section. The configuration is either keyed by section,
+// which applies to regular pages and taxonomies, e.g.
+//
+//	[permalinks]
+//	posts = "/:year/:month/:title/"
+//
+// Or keyed by page kind, e.g.
+//
+//	[permalinks.section]
+//	posts = "/articles/"
+//	[permalinks.taxonomy]
+//	tags = "/topics/:slug/"
+//
+// Patterns set for a page kind take precedence.
+func decodePermalinksConfig(v interface{}) (map[string]map[string]string, error) {
+	patterns := make(map[string]map[string]string)
+	if v == nil {
+		return patterns, nil
+	}
+
+	var m map[string]interface{}
+	if sm, ok := v.(map[string]string); ok {
+		m = make(map[string]interface{}, len(sm))
+		for k, pattern := range sm {
+			m[k] = pattern
+		}
+	} else {
+		var err error
+		m, err = cast.ToStringMapE(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode permalinks")
+		}
+	}
+
+	set := func(kind, section, pattern string) {
+		if patterns[kind] == nil {
+			patterns[kind] = make(map[string]string)
+		}
+		patterns[kind][section] = pattern
+	}
+
+	for section, vv := range m {
+		if pattern, ok := vv.(string); ok {
+			set(KindPage, section, pattern)
+			set(KindTaxonomy, section, pattern)
+		}
+	}
+
+	for k, vv := range m {
+		if _, ok := vv.(string); ok {
+			continue
+		}
+
+		kind, found := permalinksKinds[strings.ToLower(k)]
+		if !found {
+			return nil, errors.Errorf("permalinks: %q is not a page kind", k)
+		}
+
+		kindPatterns, err := cast.ToStringMapStringE(vv)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode permalinks for %q", k)
+		}
+
+		for section, pattern := range kindPatterns {
+			set(kind, section, pattern)
+		}
+	}
+
+	return patterns, nil
+}
+
+// Expand expands the path in p according to the rules defined for the given key
+// and the page's kind.
 // If no rules are found for the given key, an empty string is returned.
 func (l PermalinkExpander) Expand(key string, p Page) (string, error) {
-	expand, found := l.expanders[key]
+	expand, found := l.expanders[p.Kind()][key]
 
 	if !found {
 		return "", nil
@@ -96,20 +187,19 @@ func (l PermalinkExpander) parse(patterns map[string]string) (map[string]func(Pa
 		}
 
 		pattern := pattern
-		matches := attributeRegexp.FindAllStringSubmatch(pattern, -1)
+		matches := attributeRegexp.FindAllStringIndex(pattern, -1)
 
 		callbacks := make([]pageToPermaAttribute, len(matches))
-		replacements := make([]string, len(matches))
+		attrs := make([]string, len(matches))
 		for i, m := range matches {
-			replacement := m[0]
-			attr := replacement[1:]
-			replacements[i] = replacement
-			callback, ok := l.knownPermalinkAttributes[attr]
+			attr := pattern[m[0]+1 : m[1]]
+			callback, ok := l.callback(attr)
 
 			if !ok {
 				return nil, &permalinkExpandError{pattern: pattern, err: errPermalinkAttributeUnknown}
 			}
 
+			attrs[i] = attr
 			callbacks[i] = callback
 		}
 
@@ -119,22 +209,24 @@ func (l PermalinkExpander) parse(patterns map[string]string) (map[string]func(Pa
 				return pattern, nil
 			}
 
-			newField := pattern
+			var b strings.Builder
+			pos := 0
 
-			for i, replacement := range replacements {
-				attr := replacement[1:]
-				callback := callbacks[i]
-				newAttr, err := callback(p, attr)
+			for i, m := range matches {
+				newAttr, err := callbacks[i](p, attrs[i])
 
 				if err != nil {
 					return "", &permalinkExpandError{pattern: pattern, err: err}
 				}
 
-				newField = strings.Replace(newField, replacement, newAttr, 1)
-
+				b.WriteString(pattern[pos:m[0]])
+				b.WriteString(newAttr)
+				pos = m[1]
 			}
 
-			return newField, nil
+			b.WriteString(pattern[pos:])
+
+			return b.String(), nil
 
 		}
 
@@ -143,11 +235,25 @@ func (l PermalinkExpander) parse(patterns map[string]string) (map[string]func(Pa
 	return expanders, nil
 }
 
+// callback returns the function to expand the given attribute.
+func (l PermalinkExpander) callback(attr string) (pageToPermaAttribute, bool) {
+	if strings.HasPrefix(attr, paramsPermalinkPrefix) {
+		return l.pageToPermalinkParam, true
+	}
+
+	callback, ok := l.knownPermalinkAttributes[attr]
+	return callback, ok
+}
+
 // pageToPermaAttribute is the type of a function which, given a page and a tag
 // can return a string to go in that position in the page (or an error)
 type pageToPermaAttribute func(Page, string) (string, error)
 
-var attributeRegexp = regexp.MustCompile(`:\w+`)
+// paramsPermalinkPrefix is the prefix of the attributes for front matter
+// params, e.g. :params.series.
+const paramsPermalinkPrefix = "params."
+
+var attributeRegexp = regexp.MustCompile(`:(?:params(?:\.\w+)+|\w+)`)
 
 // validate determines if a PathPattern is well-formed
 func (l PermalinkExpander) validate(pp string) bool {
@@ -169,7 +275,7 @@ func (l PermalinkExpander) validate(pp string) bool {
 
 		for _, match := range matches {
 			k := strings.ToLower(match[0][1:])
-			if _, ok := l.knownPermalinkAttributes[k]; !ok {
+			if _, ok := l.callback(k); !ok {
 				return false
 			}
 		}
@@ -246,3 +352,68 @@ func (l PermalinkExpander) pageToPermalinkSection(p Page, _ string) (string, err
 func (l PermalinkExpander) pageToPermalinkSections(p Page, _ string) (string, error) {
 	return p.CurrentSection().SectionsPath(), nil
 }
+
+// pageToPermalinkContentBaseName returns the URL-safe form of the content
+// file's base name, which is the directory name for bundles.
+func (l PermalinkExpander) pageToPermalinkContentBaseName(p Page, _ string) (string, error) {
+	if p.File().IsZero() {
+		return "", nil
+	}
+
+	name := p.File().TranslationBaseName()
+	if name == "index" || name == "_index" {
+		dir := strings.TrimSuffix(p.File().Dir(), helpers.FilePathSeparator)
+		_, name = filepath.Split(dir)
+	}
+
+	return l.ps.URLize(name), nil
+}
+
+// if the page has a slug, return the slug, else return the content base name
+func (l PermalinkExpander) pageToPermalinkSlugElseContentBaseName(p Page, a string) (string, error) {
+	if p.Slug() != "" {
+		return l.ps.URLize(p.Slug()), nil
+	}
+	return l.pageToPermalinkContentBaseName(p, a)
+}
+
+func (l PermalinkExpander) pageToPermalinkLang(p Page, _ string) (string, error) {
+	return p.Lang(), nil
+}
+
+// pageToPermalinkParam returns the URL-safe form of the front matter param
+// in attr, e.g. params.series. For a list of values, the first is used.
+func (l PermalinkExpander) pageToPermalinkParam(p Page, attr string) (string, error) {
+	var v interface{} = p.Params()
+	for _, key := range strings.Split(strings.ToLower(strings.TrimPrefix(attr, paramsPermalinkPrefix)), ".") {
+		m, err := cast.ToStringMapE(v)
+		if err != nil {
+			return "", nil
+		}
+		v = m[key]
+	}
+
+	switch vv := v.(type) {
+	case nil:
+		return "", nil
+	case time.Time:
+		return vv.Format("2006-01-02"), nil
+	case []string:
+		if len(vv) == 0 {
+			return "", nil
+		}
+		return l.ps.URLize(vv[0]), nil
+	case []interface{}:
+		if len(vv) == 0 {
+			return "", nil
+		}
+		v = vv[0]
+	}
+
+	s, err := cast.ToStringE(v)
+	if err != nil {
+		return "", errors.Errorf("unsupported type %T for param %q", v, attr)
+	}
+
+	return l.ps.URLize(s), nil
+}
@@ -36,12 +36,20 @@ var testdataPermalinks = []struct {
 	{"/:title/", true, "/spf13-vim-3.0-release-and-new-website/"}, // Title
 	{"/:slug/", true, "/the-slug/"},                               // Slug
 	{"/:filename/", true, "/test-page/"},                          // Filename
+	{"/:contentbasename/", true, "/test-page/"},                   // Content base name
+	{"/:slugorcontentbasename/", true, "/the-slug/"},              // Slug or content base name
+	{"/:lang/:slug/", true, "/en/the-slug/"},                      // Language
+	{"/:params.series/:slug/", true, "/hugo-tips/the-slug/"},      // Param
+	{"/:params.author.name/", true, "/jane-doe/"},                 // Nested param
+	{"/:params.tags/", true, "/go/"},                              // First value of a list param
+	{"/:slug:params.series/", true, "/the-slughugo-tips/"},        // Adjacent attributes
 	// TODO(moorereason): need test scaffolding for this.
 	//{"/:sections/", false, "/blue/"},                              // Sections
 
 	// Failures
 	{"/blog/:fred", false, ""},
 	{"/:year//:title", false, ""},
+	{"/:params/", false, ""},
 }
 
 func TestPermalinkExpansion(t *testing.T) {
@@ -55,6 +63,10 @@ func TestPermalinkExpansion(t *testing.T) {
 	page.date = d
 	page.section = "blue"
 	page.slug = "The Slug"
+	page.lang = "en"
+	page.params["series"] = "Hugo Tips"
+	page.params["author"] = map[string]interface{}{"name": "Jane Doe"}
+	page.params["tags"] = []string{"Go", "Hugo"}
 
 	for i, item := range testdataPermalinks {
 
@@ -114,6 +126,59 @@ func TestPermalinkExpansionMultiSection(t *testing.T) {
 
 }
 
+func TestPermalinkExpansionKinds(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	page := newTestPageWithFile("/blog/_index.md")
+	page.title = "My Blog"
+	page.section = "blog"
+
+	ps := newTestPathSpec()
+	ps.Cfg.Set("permalinks", map[string]interface{}{
+		"blog": "/:year/:slug/",
+		"section": map[string]interface{}{
+			"blog": "/articles/:contentbasename/",
+		},
+		"taxonomy": map[string]interface{}{
+			"blog": "/topics/:slug/",
+		},
+	})
+
+	expander, err := NewPermalinkExpander(ps)
+	assert.NoError(err)
+
+	page.kind = KindSection
+	expanded, err := expander.Expand("blog", page)
+	assert.NoError(err)
+	assert.Equal("/articles/blog/", expanded)
+
+	page.kind = KindTaxonomy
+	expanded, err = expander.Expand("blog", page)
+	assert.NoError(err)
+	assert.Equal("/topics/my-blog/", expanded)
+
+	page.kind = KindTaxonomyTerm
+	expanded, err = expander.Expand("blog", page)
+	assert.NoError(err)
+	assert.Equal("", expanded)
+
+	page.kind = KindPage
+	expanded, err = expander.Expand("blog", page)
+	assert.NoError(err)
+	assert.Equal("/1/my-blog/", expanded)
+
+	ps.Cfg.Set("permalinks", map[string]interface{}{
+		"blogs": map[string]interface{}{
+			"blog": "/:slug/",
+		},
+	})
+
+	_, err = NewPermalinkExpander(ps)
+	assert.Error(err)
+}
+
 func TestPermalinkExpansionConcurrent(t *testing.T) {
 	t.Parallel()
 
@@ -53,6 +53,7 @@ func newTestPageWithFile(filename string) *testPage {
 	filename = filepath.FromSlash(filename)
 	file := source.NewTestFile(filename)
 	return &testPage{
+		kind:   KindPage,
 		params: make(map[string]interface{}),
 		data:   make(map[string]interface{}),
 		file:   file,
@@ -74,6 +75,8 @@ func newTestPathSpecFor(cfg config.Provider) *helpers.PathSpec {
 }
 
 type testPage struct {
+	kind        string
+	lang        string
 	description string
 	title       string
 	linkTitle   string
@@ -110,6 +113,10 @@ func (p *testPage) AllTranslations() Pages {
 	panic("not implemented")
 }
 
+func (p *testPage) Ancestors() Pages {
+	panic("not implemented")
+}
+
 func (p *testPage) AlternativeOutputFormats() OutputFormats {
 	panic("not implemented")
 }
@@ -278,11 +285,11 @@ func (p *testPage) Keywords() []string {
 }
 
 func (p *testPage) Kind() string {
-	panic("not implemented")
+	return p.kind
 }
 
 func (p *testPage) Lang() string {
-	panic("not implemented")
+	return p.lang
 }
 
 func (p *testPage) Language() *langs.Language {
@@ -364,6 +371,18 @@ func (p *testPage) Page() Page {
 	return p
 }
 
+func (p *testPage) Backlinks() Pages {
+	panic("not implemented")
+}
+
+func (p *testPage) Breadcrumbs() Pages {
+	panic("not implemented")
+}
+
+func (p *testPage) Children() Pages {
+	panic("not implemented")
+}
+
 func (p *testPage) Parent() Page {
 	panic("not implemented")
 }
@@ -384,6 +403,10 @@ func (p *testPage) PlainWords() []string {
 	panic("not implemented")
 }
 
+func (p *testPage) PositionIn(taxonomy, term string) (*TermPosition, error) {
+	panic("not implemented")
+}
+
 func (p *testPage) Prev() Page {
 	panic("not implemented")
 }
@@ -497,6 +520,10 @@ func (p *testPage) TableOfContents() template.HTML {
 	panic("not implemented")
 }
 
+func (p *testPage) TOC() *helpers.TableOfContents {
+	panic("not implemented")
+}
+
 func (p *testPage) Title() string {
 	return p.title
 }
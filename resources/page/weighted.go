@@ -128,6 +128,58 @@ func (wp WeightedPages) Next(cur Page) Page {
 	return nil
 }
 
+// TermPosition describes the position of a page in the weighted pages of a
+// taxonomy term, e.g. "part 3 of 7" of a series.
+type TermPosition struct {
+	// The 0-based index of the page in Pages.
+	Index int
+
+	// The 1-based position of the page, i.e. Index + 1.
+	Number int
+
+	// The number of pages in the term.
+	Total int
+
+	// The page before this page in the term, nil if this is the first.
+	Prev Page
+
+	// The page after this page in the term, nil if this is the last.
+	Next Page
+
+	// All the pages in the term, in order.
+	Pages WeightedPages
+}
+
+// NewTermPosition creates a new TermPosition for the page at index i in wp.
+func NewTermPosition(wp WeightedPages, i int) *TermPosition {
+	pos := &TermPosition{
+		Index:  i,
+		Number: i + 1,
+		Total:  len(wp),
+		Pages:  wp,
+	}
+
+	if i > 0 {
+		pos.Prev = wp[i-1].Page
+	}
+
+	if i < len(wp)-1 {
+		pos.Next = wp[i+1].Page
+	}
+
+	return pos
+}
+
+// IsFirst returns whether this is the first page in the term.
+func (pos *TermPosition) IsFirst() bool {
+	return pos.Index == 0
+}
+
+// IsLast returns whether this is the last page in the term.
+func (pos *TermPosition) IsLast() bool {
+	return pos.Index == pos.Total-1
+}
+
 func (wp WeightedPages) Len() int      { return len(wp) }
 func (wp WeightedPages) Swap(i, j int) { wp[i], wp[j] = wp[j], wp[i] }
 
@@ -34,6 +34,7 @@ import (
 	"github.com/gohugoio/hugo/common/collections"
 	"github.com/gohugoio/hugo/common/hugio"
 	"github.com/gohugoio/hugo/common/loggers"
+	"github.com/gohugoio/hugo/resources/exif"
 	"github.com/gohugoio/hugo/resources/page"
 	"github.com/gohugoio/hugo/resources/resource"
 
@@ -80,6 +81,10 @@ type Spec struct {
 	// Holds default filter settings etc.
 	imaging *Imaging
 
+	exifDecoder *exif.Decoder
+
+	imageProcessing *imageProcessing
+
 	imageCache    *imageCache
 	ResourceCache *ResourceCache
 	FileCaches    filecache.Caches
@@ -97,6 +102,16 @@ func NewSpec(
 		return nil, err
 	}
 
+	exifDecoder, err := exif.NewDecoder(
+		exif.IncludeFields(imaging.Exif.IncludeFields),
+		exif.ExcludeFields(imaging.Exif.ExcludeFields),
+		exif.WithDateDisabled(imaging.Exif.DisableDate),
+		exif.WithLatLongDisabled(imaging.Exif.DisableLatLong),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	if logger == nil {
 		logger = loggers.NewErrorLogger()
 	}
@@ -109,6 +124,7 @@ func NewSpec(
 	rs := &Spec{PathSpec: s,
 		Logger:        logger,
 		imaging:       &imaging,
+		exifDecoder:   exifDecoder,
 		MediaTypes:    mimeTypes,
 		OutputFormats: outputFormats,
 		Permalinks:    permalinks,
@@ -119,6 +135,7 @@ func NewSpec(
 			s,
 		)}
 
+	rs.imageProcessing = newImageProcessing(rs.imaging, logger)
 	rs.ResourceCache = newResourceCache(rs)
 
 	return rs, nil
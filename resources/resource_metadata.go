@@ -29,7 +29,8 @@ import (
 )
 
 var (
-	_ metaAssigner = (*genericResource)(nil)
+	_ metaAssigner       = (*genericResource)(nil)
+	_ focalPointAssigner = (*Image)(nil)
 )
 
 // metaAssigner allows updating metadata in resources that supports it.
@@ -39,12 +40,19 @@ type metaAssigner interface {
 	updateParams(params map[string]interface{})
 }
 
+// focalPointAssigner is implemented by resources that have a focal point,
+// i.e. images.
+type focalPointAssigner interface {
+	setFocalPoint(fp *focalPoint)
+}
+
 const counterPlaceHolder = ":counter"
 
 // AssignMetadata assigns the given metadata to those resources that supports updates
 // and matching by wildcard given in `src` using `filepath.Match` with lower cased values.
 // This assignment is additive, but the most specific match needs to be first.
 // The `name` and `title` metadata field support shell-matched collection it got a match in.
+// The `focalPoint` metadata field, e.g. [30, 60], sets the focal point of images in percent.
 // See https://golang.org/pkg/path/#Match
 func AssignMetadata(metadata []map[string]interface{}, resources ...resource.Resource) error {
 	counters := make(map[string]int)
@@ -55,7 +63,7 @@ func AssignMetadata(metadata []map[string]interface{}, resources ...resource.Res
 		}
 
 		var (
-			nameSet, titleSet                   bool
+			nameSet, titleSet, focalPointSet    bool
 			nameCounter, titleCounter           = 0, 0
 			nameCounterFound, titleCounterFound bool
 			resourceSrcKey                      = strings.ToLower(r.Name())
@@ -113,6 +121,19 @@ func AssignMetadata(metadata []map[string]interface{}, resources ...resource.Res
 					}
 				}
 
+				if !focalPointSet {
+					if fpa, ok := r.(focalPointAssigner); ok {
+						if v, found := getMetaValue(meta, "focalPoint"); found {
+							fp, err := parseFocalPoint(v)
+							if err != nil {
+								return errors.Wrapf(err, "failed to assign focal point to %q", r.Name())
+							}
+							fpa.setFocalPoint(fp)
+							focalPointSet = true
+						}
+					}
+				}
+
 				params, found := meta["params"]
 				if found {
 					m := cast.ToStringMap(params)
@@ -127,6 +148,16 @@ func AssignMetadata(metadata []map[string]interface{}, resources ...resource.Res
 	return nil
 }
 
+// getMetaValue gets the value of the key, matched case insensitively, in meta.
+func getMetaValue(meta map[string]interface{}, key string) (interface{}, bool) {
+	for k, v := range meta {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
 func replaceResourcePlaceholders(in string, counter int) string {
 	return strings.Replace(in, counterPlaceHolder, strconv.Itoa(counter), -1)
 }
@@ -78,3 +78,32 @@ func smartCrop(img image.Image, width, height int, anchor imaging.Anchor, filter
 	return imaging.Resize(cropped, width, height, filter), nil
 
 }
+
+// smartCropNoResize crops img to the given dimensions without resizing,
+// centered on the best crop found by Smart Crop.
+func smartCropNoResize(img image.Image, width, height int, filter imaging.ResampleFilter) (*image.NRGBA, error) {
+	if width <= 0 || height <= 0 {
+		return &image.NRGBA{}, nil
+	}
+
+	b := img.Bounds()
+
+	if b.Dx() <= 0 || b.Dy() <= 0 {
+		return &image.NRGBA{}, nil
+	}
+
+	smart := newSmartCropAnalyzer(filter)
+
+	rect, err := smart.FindBestCrop(img, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	c := rect.Min.Add(rect.Max).Div(2)
+	fp := focalPoint{
+		X: float64(c.X-b.Min.X) * 100 / float64(b.Dx()),
+		Y: float64(c.Y-b.Min.Y) * 100 / float64(b.Dy()),
+	}
+
+	return imaging.Crop(img, fp.rect(b, width, height)), nil
+}
@@ -25,7 +25,14 @@ import (
 
 var comp = compare.New()
 
-// Sort returns a sorted sequence.
+// Sort returns a sorted sequence. The sort keys are given as a key followed
+// by an optional sort direction, "asc" (default) or "desc", e.g.
+//
+//	{{ sort .Pages "Weight" "Params.series_order" "Date" "desc" }}
+//
+// A key is a dot chained path to a field, method or map value of the
+// elements, or "value" to sort by the elements themselves. Maps are by
+// default sorted by their keys. The sort is stable.
 func (ns *Namespace) Sort(seq interface{}, args ...interface{}) (interface{}, error) {
 	if seq == nil {
 		return nil, errors.New("sequence must be provided")
@@ -44,90 +51,143 @@ func (ns *Namespace) Sort(seq interface{}, args ...interface{}) (interface{}, er
 		return nil, errors.New("can't sort " + reflect.ValueOf(seq).Type().String())
 	}
 
+	keys, err := parseSortKeys(args)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a list of pairs that will be used to do the sort
-	p := pairList{SortAsc: true, SliceType: reflect.SliceOf(seqv.Type().Elem())}
+	p := pairList{Keys: keys, SliceType: reflect.SliceOf(seqv.Type().Elem())}
 	p.Pairs = make([]pair, seqv.Len())
 
-	var sortByField string
-	for i, l := range args {
-		dStr, err := cast.ToStringE(l)
-		switch {
-		case i == 0 && err != nil:
-			sortByField = ""
-		case i == 0 && err == nil:
-			sortByField = dStr
-		case i == 1 && err == nil && dStr == "desc":
-			p.SortAsc = false
-		case i == 1:
-			p.SortAsc = true
-		}
-	}
-	path := strings.Split(strings.Trim(sortByField, "."), ".")
-
 	switch seqv.Kind() {
 	case reflect.Array, reflect.Slice:
 		for i := 0; i < seqv.Len(); i++ {
 			p.Pairs[i].Value = seqv.Index(i)
-			if sortByField == "" || sortByField == "value" {
-				p.Pairs[i].Key = p.Pairs[i].Value
-			} else {
-				v := p.Pairs[i].Value
-				var err error
-				for _, elemName := range path {
-					v, err = evaluateSubElem(v, elemName)
-					if err != nil {
-						return nil, err
-					}
-				}
-				p.Pairs[i].Key = v
+			if p.Pairs[i].Keys, err = sortKeyValues(keys, p.Pairs[i].Value, p.Pairs[i].Value); err != nil {
+				return nil, err
 			}
 		}
 
 	case reflect.Map:
-		keys := seqv.MapKeys()
+		mapKeys := seqv.MapKeys()
 		for i := 0; i < seqv.Len(); i++ {
-			p.Pairs[i].Value = seqv.MapIndex(keys[i])
-			if sortByField == "" {
-				p.Pairs[i].Key = keys[i]
-			} else if sortByField == "value" {
-				p.Pairs[i].Key = p.Pairs[i].Value
-			} else {
-				v := p.Pairs[i].Value
-				var err error
-				for _, elemName := range path {
-					v, err = evaluateSubElem(v, elemName)
-					if err != nil {
-						return nil, err
-					}
-				}
-				p.Pairs[i].Key = v
+			p.Pairs[i].Value = seqv.MapIndex(mapKeys[i])
+			if p.Pairs[i].Keys, err = sortKeyValues(keys, mapKeys[i], p.Pairs[i].Value); err != nil {
+				return nil, err
 			}
 		}
 	}
 	return p.sort(), nil
 }
 
+// sortKey is a key to sort by.
+type sortKey struct {
+	// The dot chained path to the value to sort by. Empty to sort by the
+	// map key for maps and the element itself for slices.
+	path []string
+
+	// Whether to sort by the element itself.
+	value bool
+
+	desc bool
+}
+
+// parseSortKeys parses the sort keys given to Sort, see Sort.
+func parseSortKeys(args []interface{}) ([]sortKey, error) {
+	var keys []sortKey
+
+	for i, arg := range args {
+		s, err := cast.ToStringE(arg)
+		if err != nil {
+			if i == 0 {
+				// Sort by the map key or the element.
+				keys = append(keys, sortKey{})
+				continue
+			}
+			return nil, errors.New("sort keys must be strings")
+		}
+
+		if dir := strings.ToLower(s); i > 0 && (dir == "asc" || dir == "desc") {
+			keys[len(keys)-1].desc = dir == "desc"
+			continue
+		}
+
+		key := sortKey{value: s == "value"}
+		if s != "" && !key.value {
+			key.path = strings.Split(strings.Trim(s, "."), ".")
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		keys = append(keys, sortKey{})
+	}
+
+	return keys, nil
+}
+
+// sortKeyValues returns the values to sort the element v with the given map
+// key, or the element itself for slices, by.
+func sortKeyValues(keys []sortKey, mapKey, v reflect.Value) ([]reflect.Value, error) {
+	values := make([]reflect.Value, len(keys))
+
+	for i, key := range keys {
+		switch {
+		case key.value:
+			values[i] = v
+		case len(key.path) == 0:
+			values[i] = mapKey
+		default:
+			kv := v
+			var err error
+			for _, elemName := range key.path {
+				kv, err = evaluateSubElem(kv, elemName)
+				if err != nil {
+					return nil, err
+				}
+			}
+			values[i] = kv
+		}
+	}
+
+	return values, nil
+}
+
 // Credit for pair sorting method goes to Andrew Gerrand
 // https://groups.google.com/forum/#!topic/golang-nuts/FT7cjmcL7gw
 // A data structure to hold a key/value pair.
 type pair struct {
-	Key   reflect.Value
+	Keys  []reflect.Value
 	Value reflect.Value
 }
 
 // A slice of pairs that implements sort.Interface to sort by Value.
 type pairList struct {
 	Pairs     []pair
-	SortAsc   bool
+	Keys      []sortKey
 	SliceType reflect.Type
 }
 
 func (p pairList) Swap(i, j int) { p.Pairs[i], p.Pairs[j] = p.Pairs[j], p.Pairs[i] }
 func (p pairList) Len() int      { return len(p.Pairs) }
 func (p pairList) Less(i, j int) bool {
-	iv := p.Pairs[i].Key
-	jv := p.Pairs[j].Key
+	for k, key := range p.Keys {
+		iv := p.Pairs[i].Keys[k]
+		jv := p.Pairs[j].Keys[k]
+
+		if lt(iv, jv) {
+			return !key.desc
+		}
+		if lt(jv, iv) {
+			return key.desc
+		}
+	}
 
+	return false
+}
+
+func lt(iv, jv reflect.Value) bool {
 	if iv.IsValid() {
 		if jv.IsValid() {
 			// can only call Interface() on valid reflect Values
@@ -147,11 +207,7 @@ func (p pairList) Less(i, j int) bool {
 
 // sorts a pairList and returns a slice of sorted values
 func (p pairList) sort() interface{} {
-	if p.SortAsc {
-		sort.Sort(p)
-	} else {
-		sort.Sort(sort.Reverse(p))
-	}
+	sort.Stable(p)
 	sorted := reflect.MakeSlice(p.SliceType, len(p.Pairs), len(p.Pairs))
 	for i, v := range p.Pairs {
 		sorted.Index(i).Set(v.Value)
@@ -235,3 +235,62 @@ func TestSort(t *testing.T) {
 		}
 	}
 }
+
+func TestSortMultipleKeys(t *testing.T) {
+	t.Parallel()
+
+	ns := New(&deps.Deps{})
+
+	type ts struct {
+		Weight int
+		Title  string
+		Params map[string]interface{}
+	}
+
+	seq := []ts{
+		{Weight: 2, Title: "a", Params: map[string]interface{}{"order": 1}},
+		{Weight: 1, Title: "b", Params: map[string]interface{}{"order": 2}},
+		{Weight: 1, Title: "c", Params: map[string]interface{}{"order": 1}},
+		{Weight: 1, Title: "d", Params: map[string]interface{}{"order": 2}},
+		{Weight: 2, Title: "e", Params: map[string]interface{}{"order": 1}},
+	}
+
+	titles := func(v interface{}) string {
+		var s string
+		for _, e := range v.([]ts) {
+			s += e.Title
+		}
+		return s
+	}
+
+	for i, test := range []struct {
+		args   []interface{}
+		expect interface{}
+	}{
+		{[]interface{}{"Weight"}, "bcdae"},
+		{[]interface{}{"Weight", "desc"}, "aebcd"},
+		{[]interface{}{"Weight", "Params.order"}, "cbdae"},
+		{[]interface{}{"Weight", "asc", "Params.order", "desc"}, "bdcae"},
+		{[]interface{}{"Weight", "desc", "Params.order", "desc", "Title", "desc"}, "eadbc"},
+		{[]interface{}{"Weight", "DESC", "Title"}, "aebcd"},
+		{[]interface{}{"Weight", 32}, false},
+	} {
+		result, err := ns.Sort(seq, test.args...)
+
+		if b, ok := test.expect.(bool); ok && !b {
+			if err == nil {
+				t.Errorf("[%d] Sort didn't return an expected error", i)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%d] failed: %s", i, err)
+			continue
+		}
+
+		if got := titles(result); got != test.expect {
+			t.Errorf("[%d] Sort with %v: got %q but expected %q", i, test.args, got, test.expect)
+		}
+	}
+}
@@ -15,7 +15,6 @@
 package images
 
 import (
-	"errors"
 	"image"
 	"sync"
 
@@ -28,6 +27,8 @@ import (
 	_ "golang.org/x/image/webp"
 
 	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/resources"
+	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 )
 
@@ -85,3 +86,150 @@ func (ns *Namespace) Config(path interface{}) (image.Config, error) {
 
 	return config, nil
 }
+
+// Filter applies the given filters to the image given as the last argument,
+// e.g. {{ $img | images.Filter (images.Blur 6) images.Grayscale }}.
+func (ns *Namespace) Filter(args ...interface{}) (*resources.Image, error) {
+	if len(args) < 2 {
+		return nil, errors.New("must provide an image and one or more filters")
+	}
+
+	img, ok := args[len(args)-1].(*resources.Image)
+	if !ok {
+		return nil, errors.Errorf("%T is not an image", args[len(args)-1])
+	}
+
+	return img.Filter(args[:len(args)-1]...)
+}
+
+// Grayscale creates a filter that produces a grayscale version of an image.
+func (ns *Namespace) Grayscale() resources.ImageFilter {
+	return resources.GrayscaleFilter()
+}
+
+// Invert creates a filter that negates the colors of an image.
+func (ns *Namespace) Invert() resources.ImageFilter {
+	return resources.InvertFilter()
+}
+
+// Blur creates a filter that applies a gaussian blur with the given sigma
+// to an image.
+func (ns *Namespace) Blur(sigma interface{}) (resources.ImageFilter, error) {
+	v, err := cast.ToFloat64E(sigma)
+	if err != nil {
+		return nil, err
+	}
+	return resources.BlurFilter(v)
+}
+
+// Sharpen creates a filter that sharpens an image with the given sigma.
+func (ns *Namespace) Sharpen(sigma interface{}) (resources.ImageFilter, error) {
+	v, err := cast.ToFloat64E(sigma)
+	if err != nil {
+		return nil, err
+	}
+	return resources.SharpenFilter(v)
+}
+
+// Brightness creates a filter that changes the brightness of an image by the
+// given percentage (-100 to 100).
+func (ns *Namespace) Brightness(percentage interface{}) (resources.ImageFilter, error) {
+	v, err := cast.ToFloat64E(percentage)
+	if err != nil {
+		return nil, err
+	}
+	return resources.BrightnessFilter(v)
+}
+
+// Contrast creates a filter that changes the contrast of an image by the
+// given percentage (-100 to 100).
+func (ns *Namespace) Contrast(percentage interface{}) (resources.ImageFilter, error) {
+	v, err := cast.ToFloat64E(percentage)
+	if err != nil {
+		return nil, err
+	}
+	return resources.ContrastFilter(v)
+}
+
+// Saturation creates a filter that changes the saturation of an image by the
+// given percentage (-100 to 500).
+func (ns *Namespace) Saturation(percentage interface{}) (resources.ImageFilter, error) {
+	v, err := cast.ToFloat64E(percentage)
+	if err != nil {
+		return nil, err
+	}
+	return resources.SaturationFilter(v)
+}
+
+// Gamma creates a filter that applies a gamma correction to an image.
+func (ns *Namespace) Gamma(gamma interface{}) (resources.ImageFilter, error) {
+	v, err := cast.ToFloat64E(gamma)
+	if err != nil {
+		return nil, err
+	}
+	return resources.GammaFilter(v)
+}
+
+// Sepia creates a filter that produces a sepia-toned version of an image.
+// The percentage (0 to 100) is the strength of the effect.
+func (ns *Namespace) Sepia(percentage interface{}) (resources.ImageFilter, error) {
+	v, err := cast.ToFloat64E(percentage)
+	if err != nil {
+		return nil, err
+	}
+	return resources.SepiaFilter(v)
+}
+
+// Colorize creates a filter that produces a colorized version of an image
+// with the given hue (0 to 360) and saturation (0 to 100). The percentage
+// (0 to 100) is the strength of the effect.
+func (ns *Namespace) Colorize(hue, saturation, percentage interface{}) (resources.ImageFilter, error) {
+	h, err := cast.ToFloat64E(hue)
+	if err != nil {
+		return nil, err
+	}
+	s, err := cast.ToFloat64E(saturation)
+	if err != nil {
+		return nil, err
+	}
+	p, err := cast.ToFloat64E(percentage)
+	if err != nil {
+		return nil, err
+	}
+	return resources.ColorizeFilter(h, s, p)
+}
+
+// Pixelate creates a filter that pixelates an image into blocks of the given
+// size.
+func (ns *Namespace) Pixelate(size interface{}) (resources.ImageFilter, error) {
+	v, err := cast.ToIntE(size)
+	if err != nil {
+		return nil, err
+	}
+	return resources.PixelateFilter(v)
+}
+
+// Overlay creates a filter that draws the image img on top of an image at the
+// given position, e.g. a watermark. The optional opacity ranges from 0 to 1,
+// the default is 1.
+func (ns *Namespace) Overlay(img interface{}, x, y interface{}, opacity ...interface{}) (resources.ImageFilter, error) {
+	overlay, ok := img.(*resources.Image)
+	if !ok {
+		return nil, errors.Errorf("%T is not an image", img)
+	}
+	xv, err := cast.ToIntE(x)
+	if err != nil {
+		return nil, err
+	}
+	yv, err := cast.ToIntE(y)
+	if err != nil {
+		return nil, err
+	}
+	o := 1.0
+	if len(opacity) > 0 {
+		if o, err = cast.ToFloat64E(opacity[0]); err != nil {
+			return nil, err
+		}
+	}
+	return resources.OverlayFilter(overlay, xv, yv, o)
+}
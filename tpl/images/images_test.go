@@ -111,6 +111,34 @@ func TestNSConfig(t *testing.T) {
 	}
 }
 
+func TestNSFilters(t *testing.T) {
+	t.Parallel()
+
+	ns := New(&deps.Deps{})
+
+	blur, err := ns.Blur("2.5")
+	require.NoError(t, err)
+	assert.Equal(t, "blur_2.5", blur.Key())
+
+	colorize, err := ns.Colorize(120, "50", 75)
+	require.NoError(t, err)
+	assert.Equal(t, "colorize_120_50_75", colorize.Key())
+
+	assert.Equal(t, "grayscale", ns.Grayscale().Key())
+
+	_, err = ns.Blur("foo")
+	require.Error(t, err)
+	_, err = ns.Pixelate(-1)
+	require.Error(t, err)
+	_, err = ns.Overlay("foo.png", 0, 0)
+	require.Error(t, err)
+
+	_, err = ns.Filter(blur)
+	require.Error(t, err)
+	_, err = ns.Filter(blur, "foo.png")
+	require.Error(t, err)
+}
+
 func blankImage(width, height int) []byte {
 	var buf bytes.Buffer
 	img := image.NewRGBA(image.Rect(0, 0, width, height))
@@ -34,6 +34,71 @@ func init() {
 			[][2]string{},
 		)
 
+		ns.AddMethodMapping(ctx.Filter,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Grayscale,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Invert,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Blur,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Sharpen,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Brightness,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Contrast,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Saturation,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Gamma,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Sepia,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Colorize,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Pixelate,
+			nil,
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Overlay,
+			nil,
+			[][2]string{},
+		)
+
 		return ns
 
 	}